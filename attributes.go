@@ -0,0 +1,136 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// AttributeFilter controls which context attributes are sent to Split as attributes, where they can end up in
+// impressions, and which are hashed before leaving the process. The filter applies to the properties of tracked
+// events too. The targeting key is never an attribute. Keys read from hashed attributes, with WithTrafficTypes or
+// WithTargetingKeyFallback, are hashed too, anonymous keys are salted with HashSalt, and denied attributes cannot be
+// key attributes.
+type AttributeFilter struct {
+	// Allow, together with AllowPatterns, lists the only attributes sent to Split. When both are empty every
+	// attribute not denied is sent.
+	Allow []string
+	// AllowPatterns allows the attributes whose names match any of the patterns.
+	AllowPatterns []*regexp.Regexp
+	// Deny lists attributes never sent to Split. Denying takes precedence over allowing.
+	Deny []string
+	// DenyPatterns denies the attributes whose names match any of the patterns.
+	DenyPatterns []*regexp.Regexp
+	// Hash lists attributes sent as the hex SHA-256 of HashSalt followed by their value formatted with fmt.Sprint,
+	// so that Split can still match them against hashed segments or lists.
+	Hash []string
+	// HashSalt is prepended to values before hashing them.
+	HashSalt string
+}
+
+// WithAttributeFilter filters and hashes the context attributes before they are sent to Split.
+func WithAttributeFilter(filter AttributeFilter) Option {
+	return func(provider *SplitProvider) error {
+		provider.attributeFilter = &attributeFilter{
+			allow:         stringSet(filter.Allow),
+			allowPatterns: filter.AllowPatterns,
+			deny:          stringSet(filter.Deny),
+			denyPatterns:  filter.DenyPatterns,
+			hash:          stringSet(filter.Hash),
+			salt:          filter.HashSalt,
+		}
+		return nil
+	}
+}
+
+type attributeFilter struct {
+	allow         map[string]bool
+	allowPatterns []*regexp.Regexp
+	deny          map[string]bool
+	denyPatterns  []*regexp.Regexp
+	hash          map[string]bool
+	salt          string
+}
+
+// splitAttributes returns the attributes to send to Split for the context, or nil if there are none. The map is
+// fresh for every evaluation, since the Split SDK hands it to impression listeners that may keep it.
+func (provider *SplitProvider) splitAttributes(evalContext openfeature.FlattenedContext) map[string]any {
+	// The targeting key and overrides are not attributes, so a context holding just those needs no map at all.
+	count := len(evalContext)
+	if _, ok := evalContext[openfeature.TargetingKey]; ok {
+		count--
+	}
+	if _, ok := evalContext[provider.overridesAttribute]; ok && provider.overridesAttribute != "" {
+		count--
+	}
+	if count == 0 {
+		return nil
+	}
+	attributes := make(map[string]any, count)
+	for key, value := range evalContext {
+		if key == openfeature.TargetingKey || key == provider.overridesAttribute {
+			continue
+		}
+		if value, ok := provider.attributeFilter.apply(key, value); ok {
+			attributes[key] = value
+		}
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+	return attributes
+}
+
+// apply returns the value to send to Split for the attribute, or false if it must not be sent.
+func (filter *attributeFilter) apply(name string, value any) (any, bool) {
+	if filter == nil {
+		return value, true
+	}
+	if filter.denies(name) {
+		return nil, false
+	}
+	if (len(filter.allow) > 0 || len(filter.allowPatterns) > 0) && !filter.allow[name] && !matchesAny(filter.allowPatterns, name) {
+		return nil, false
+	}
+	if filter.hash[name] {
+		return filter.hashed(value), true
+	}
+	return value, true
+}
+
+// key returns the Split key to send for a key read from the context attribute, hashed if the attribute is.
+func (filter *attributeFilter) key(attribute string, key any) any {
+	if filter == nil || !filter.hash[attribute] {
+		return key
+	}
+	return filter.hashed(key)
+}
+
+func (filter *attributeFilter) denies(name string) bool {
+	return filter != nil && (filter.deny[name] || matchesAny(filter.denyPatterns, name))
+}
+
+func (filter *attributeFilter) hashed(value any) string {
+	sum := sha256.Sum256([]byte(filter.salt + fmt.Sprint(value)))
+	return hex.EncodeToString(sum[:])
+}
+
+func matchesAny(patterns []*regexp.Regexp, name string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
@@ -0,0 +1,113 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Attribute filter", func() {
+	var (
+		mockSplitClient *mocks.MockSplitClient
+		evalCtx         openfeature.FlattenedContext
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		evalCtx = openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			"plan":                   "pro",
+			"email":                  "jane@example.com",
+			"ip":                     "10.0.0.1",
+			"internal.trace":         "abc",
+		}
+	})
+
+	evaluateWith := func(filter AttributeFilter, expected map[string]any) {
+		subject, err := NewProvider(mockSplitClient, WithAttributeFilter(filter))
+		Ω(err).ShouldNot(HaveOccurred())
+		mockSplitClient.EXPECT().Treatment("user-1", "checkout", expected).Return("on")
+
+		detail := subject.BooleanEvaluation(context.Background(), "checkout", false, evalCtx)
+
+		Ω(detail.Value).Should(BeTrue())
+	}
+
+	It("removes denied attributes", func() {
+		evaluateWith(AttributeFilter{
+			Deny:         []string{"email"},
+			DenyPatterns: []*regexp.Regexp{regexp.MustCompile(`^internal\.`)},
+		}, map[string]any{"plan": "pro", "ip": "10.0.0.1"})
+	})
+
+	It("only sends allowed attributes, unless denied", func() {
+		evaluateWith(AttributeFilter{
+			Allow:         []string{"plan", "email"},
+			AllowPatterns: []*regexp.Regexp{regexp.MustCompile(`^internal\.`)},
+			Deny:          []string{"email"},
+		}, map[string]any{"plan": "pro", "internal.trace": "abc"})
+	})
+
+	It("hashes sensitive attributes", func() {
+		sum := sha256.Sum256([]byte("salt:jane@example.com"))
+
+		evaluateWith(AttributeFilter{
+			Allow:    []string{"plan", "email"},
+			Hash:     []string{"email"},
+			HashSalt: "salt:",
+		}, map[string]any{"plan": "pro", "email": hex.EncodeToString(sum[:])})
+	})
+
+	It("sends no attributes when all are filtered out", func() {
+		evaluateWith(AttributeFilter{Allow: []string{"country"}}, nil)
+	})
+
+	It("hashes keys read from hashed attributes", func() {
+		sum := sha256.Sum256([]byte("salt:jane@example.com"))
+		subject, err := NewProvider(mockSplitClient,
+			WithAttributeFilter(AttributeFilter{Allow: []string{"plan"}, Hash: []string{"email"}, HashSalt: "salt:"}),
+			WithTargetingKeyFallback("email"))
+		Ω(err).ShouldNot(HaveOccurred())
+		delete(evalCtx, openfeature.TargetingKey)
+		mockSplitClient.EXPECT().Treatment(hex.EncodeToString(sum[:]), "checkout", map[string]any{"plan": "pro"}).Return("on")
+
+		detail := subject.BooleanEvaluation(context.Background(), "checkout", false, evalCtx)
+
+		Ω(detail.Value).Should(BeTrue())
+	})
+
+	It("rejects denied key attributes", func() {
+		filter := WithAttributeFilter(AttributeFilter{DenyPatterns: []*regexp.Regexp{regexp.MustCompile(`^e`)}})
+
+		_, fallbackErr := NewProvider(mockSplitClient, WithTargetingKeyFallback("email"), filter)
+		_, trafficTypesErr := NewProvider(mockSplitClient, filter, WithTrafficTypes(TrafficTypes{Keys: map[string]string{"user": "email"}}))
+		_, anonymousErr := NewProvider(mockSplitClient, filter, WithAnonymousEvaluation(AnonymousConfig{HashAttributes: []string{"email"}}))
+
+		Ω(fallbackErr).Should(MatchError(`key attribute "email" is denied by the attribute filter`))
+		Ω(trafficTypesErr).Should(MatchError(`key attribute "email" is denied by the attribute filter`))
+		Ω(anonymousErr).Should(MatchError(`key attribute "email" is denied by the attribute filter`))
+	})
+
+	It("salts anonymous keys with the hash salt", func() {
+		sum := sha256.Sum256([]byte(`salt:["ip","10.0.0.1"]`))
+		subject, err := NewProvider(mockSplitClient,
+			WithAttributeFilter(AttributeFilter{Allow: []string{"plan"}, HashSalt: "salt:"}),
+			WithAnonymousEvaluation(AnonymousConfig{HashAttributes: []string{"ip"}}))
+		Ω(err).ShouldNot(HaveOccurred())
+		delete(evalCtx, openfeature.TargetingKey)
+		mockSplitClient.EXPECT().Treatment(hex.EncodeToString(sum[:]), "checkout", map[string]any{"plan": "pro"}).Return("on")
+
+		detail := subject.BooleanEvaluation(context.Background(), "checkout", false, evalCtx)
+
+		Ω(detail.Value).Should(BeTrue())
+	})
+})
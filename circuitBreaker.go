@@ -0,0 +1,171 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"sync"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// CircuitState is the state of the circuit breaker around the Split client.
+type CircuitState string
+
+const (
+	// CircuitClosed means calls go to the Split client as usual.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen means the Split client is considered unhealthy and is not called.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen means a single trial call is allowed through to test whether the Split client recovered.
+	CircuitHalfOpen CircuitState = "half-open"
+
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+)
+
+var errCircuitOpen = openfeature.NewProviderNotReadyResolutionError("Split client circuit breaker is open.")
+
+// CircuitBreakerConfig configures the circuit breaker around the Split client.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed calls that opens the circuit. Defaults to 5.
+	FailureThreshold int
+	// LatencyThreshold, when positive, counts calls slower than it as failed.
+	LatencyThreshold time.Duration
+	// OpenTimeout is how long the circuit stays open before a trial call is allowed. Defaults to 30 seconds.
+	OpenTimeout time.Duration
+}
+
+// WithCircuitBreaker wraps calls to the Split client in a circuit breaker. A call fails when it returns control,
+// panics or exceeds the latency threshold. While the circuit is open the Split client is not called, and flags
+// resolve to their fallback treatment or the default value. The provider emits PROVIDER_STALE when the circuit
+// opens and PROVIDER_READY when it closes again.
+func WithCircuitBreaker(config CircuitBreakerConfig) Option {
+	return func(provider *SplitProvider) error {
+		if config.FailureThreshold <= 0 {
+			config.FailureThreshold = defaultFailureThreshold
+		}
+		if config.OpenTimeout <= 0 {
+			config.OpenTimeout = defaultOpenTimeout
+		}
+		provider.breaker = &circuitBreaker{
+			config: config,
+			state:  CircuitClosed,
+			now:    time.Now,
+		}
+		return nil
+	}
+}
+
+// CircuitState returns the state of the circuit breaker, or CircuitClosed when none is configured.
+func (provider *SplitProvider) CircuitState() CircuitState {
+	return provider.breaker.current()
+}
+
+type circuitBreaker struct {
+	config   CircuitBreakerConfig
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	trial    bool
+	now      func() time.Time
+}
+
+func (breaker *circuitBreaker) current() CircuitState {
+	if breaker == nil {
+		return CircuitClosed
+	}
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	return breaker.state
+}
+
+// allow reports whether a call to the Split client may be made.
+func (breaker *circuitBreaker) allow() bool {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	switch breaker.state {
+	case CircuitOpen:
+		if breaker.now().Sub(breaker.openedAt) < breaker.config.OpenTimeout {
+			return false
+		}
+		breaker.state = CircuitHalfOpen
+		breaker.trial = true
+		return true
+	case CircuitHalfOpen:
+		if breaker.trial {
+			return false
+		}
+		breaker.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record registers the outcome of a call and returns the state the circuit moved to, if it changed
+// between closed and open.
+func (breaker *circuitBreaker) record(failed bool) (CircuitState, bool) {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	switch breaker.state {
+	case CircuitHalfOpen:
+		breaker.trial = false
+		if failed {
+			breaker.state = CircuitOpen
+			breaker.openedAt = breaker.now()
+			return "", false
+		}
+		breaker.state = CircuitClosed
+		breaker.failures = 0
+		return CircuitClosed, true
+	case CircuitClosed:
+		if !failed {
+			breaker.failures = 0
+			return "", false
+		}
+		breaker.failures++
+		if breaker.failures < breaker.config.FailureThreshold {
+			return "", false
+		}
+		breaker.state = CircuitOpen
+		breaker.openedAt = breaker.now()
+		return CircuitOpen, true
+	default:
+		return "", false
+	}
+}
+
+// guardedTreatment calls the Split client through the circuit breaker, if one is configured.
+// It returns false when the circuit is open and the client was not called. A panicking client counts as a
+// failed call.
+func (provider *SplitProvider) guardedTreatment(key any, flag string, attributes map[string]any) (treatment string, config *string, called bool, err *openfeature.ResolutionError) {
+	breaker := provider.breaker
+	if breaker == nil {
+		treatment, config, err = provider.splitTreatment(key, flag, attributes)
+		return treatment, config, true, err
+	}
+	if !breaker.allow() {
+		return "", nil, false, nil
+	}
+	start := breaker.now()
+	failed := true
+	defer func() {
+		if breaker.config.LatencyThreshold > 0 && breaker.now().Sub(start) > breaker.config.LatencyThreshold {
+			failed = true
+		}
+		if state, changed := breaker.record(failed); changed {
+			provider.circuitChanged(state)
+		}
+	}()
+	treatment, config, err = provider.splitTreatment(key, flag, attributes)
+	failed = noTreatment(treatment)
+	return treatment, config, true, err
+}
+
+func (provider *SplitProvider) circuitChanged(state CircuitState) {
+	if state == CircuitOpen {
+		provider.emit(openfeature.ProviderStale, "Split client circuit breaker opened.")
+	} else {
+		provider.emit(openfeature.ProviderReady, "Split client circuit breaker closed.")
+	}
+}
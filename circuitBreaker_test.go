@@ -0,0 +1,163 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Circuit breaker", func() {
+	var (
+		mockSplitClient *mocks.MockSplitClient
+		subject         *SplitProvider
+		key             string
+		feature         string
+		evalCtx         openfeature.FlattenedContext
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		key = uuid.NewString()
+		feature = uuid.NewString()
+		evalCtx = openfeature.FlattenedContext{
+			openfeature.TargetingKey: key,
+		}
+	})
+
+	newSubject := func(config CircuitBreakerConfig, opts ...Option) {
+		var err error
+		subject, err = NewProvider(mockSplitClient, append(opts, WithCircuitBreaker(config))...)
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+
+	It("is closed without a circuit breaker", func() {
+		subject, err := NewProvider(mockSplitClient)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(subject.CircuitState()).Should(Equal(CircuitClosed))
+	})
+
+	It("opens after consecutive control results and stops calling split", func() {
+		newSubject(CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+		mockSplitClient.EXPECT().Treatment(key, feature, nil).Return("control").Times(2)
+
+		// act
+		subject.StringEvaluation(context.Background(), feature, "", evalCtx)
+		subject.StringEvaluation(context.Background(), feature, "", evalCtx)
+		result := subject.StringEvaluation(context.Background(), feature, "default", evalCtx)
+
+		Ω(subject.CircuitState()).Should(Equal(CircuitOpen))
+		Ω(result).Should(Equal(openfeature.StringResolutionDetail{
+			Value: "default",
+			ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+				ResolutionError: openfeature.NewProviderNotReadyResolutionError("Split client circuit breaker is open."),
+				Reason:          openfeature.DefaultReason,
+			},
+		}))
+		Ω(subject.EventChannel()).Should(Receive(And(
+			HaveField("EventType", openfeature.ProviderStale),
+			HaveField("ProviderName", "Split"),
+		)))
+	})
+
+	It("resets the failure count after a successful call", func() {
+		newSubject(CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+		gomock.InOrder(
+			mockSplitClient.EXPECT().Treatment(key, feature, nil).Return("control"),
+			mockSplitClient.EXPECT().Treatment(key, feature, nil).Return("on"),
+			mockSplitClient.EXPECT().Treatment(key, feature, nil).Return("control"),
+		)
+
+		// act
+		for i := 0; i < 3; i++ {
+			subject.BooleanEvaluation(context.Background(), feature, false, evalCtx)
+		}
+
+		Ω(subject.CircuitState()).Should(Equal(CircuitClosed))
+		Ω(subject.EventChannel()).ShouldNot(Receive())
+	})
+
+	It("counts slow calls as failures", func() {
+		newSubject(CircuitBreakerConfig{FailureThreshold: 1, LatencyThreshold: time.Millisecond, OpenTimeout: time.Hour})
+		mockSplitClient.EXPECT().Treatment(key, feature, nil).DoAndReturn(func(any, string, map[string]any) string {
+			time.Sleep(5 * time.Millisecond)
+			return "on"
+		})
+
+		// act
+		result := subject.BooleanEvaluation(context.Background(), feature, false, evalCtx)
+
+		Ω(result.Value).Should(BeTrue())
+		Ω(subject.CircuitState()).Should(Equal(CircuitOpen))
+	})
+
+	It("counts panics as failures and reports them as general errors", func() {
+		newSubject(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour})
+		mockSplitClient.EXPECT().Treatment(key, feature, nil).DoAndReturn(func(any, string, map[string]any) string {
+			panic("redis unavailable")
+		})
+
+		// act
+		result := subject.BooleanEvaluation(context.Background(), feature, true, evalCtx)
+
+		Ω(result.Value).Should(BeTrue())
+		Ω(result.Reason).Should(Equal(openfeature.ErrorReason))
+		Ω(result.ResolutionError).Should(Equal(openfeature.NewGeneralResolutionError("Split client panicked: redis unavailable")))
+		Ω(subject.CircuitState()).Should(Equal(CircuitOpen))
+	})
+
+	It("serves fallback treatments while open", func() {
+		newSubject(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour},
+			WithFallbackTreatments(map[string]FallbackTreatment{feature: {Treatment: "on"}}))
+		mockSplitClient.EXPECT().Treatment(key, feature, nil).Return("control")
+
+		// act
+		subject.BooleanEvaluation(context.Background(), feature, false, evalCtx)
+		result := subject.BooleanEvaluation(context.Background(), feature, false, evalCtx)
+
+		Ω(subject.CircuitState()).Should(Equal(CircuitOpen))
+		Ω(result.Value).Should(BeTrue())
+		Ω(result.Reason).Should(Equal(openfeature.DefaultReason))
+		Ω(result.FlagMetadata).Should(HaveKeyWithValue(MetadataSourceKey, SourceFallback))
+	})
+
+	It("closes again after a successful trial call", func() {
+		newSubject(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond})
+		gomock.InOrder(
+			mockSplitClient.EXPECT().Treatment(key, feature, nil).Return("control"),
+			mockSplitClient.EXPECT().Treatment(key, feature, nil).Return("on"),
+		)
+		subject.BooleanEvaluation(context.Background(), feature, false, evalCtx)
+		Ω(subject.EventChannel()).Should(Receive(HaveField("EventType", openfeature.ProviderStale)))
+		time.Sleep(20 * time.Millisecond)
+
+		// act
+		result := subject.BooleanEvaluation(context.Background(), feature, false, evalCtx)
+
+		Ω(result.Value).Should(BeTrue())
+		Ω(subject.CircuitState()).Should(Equal(CircuitClosed))
+		Ω(subject.EventChannel()).Should(Receive(HaveField("EventType", openfeature.ProviderReady)))
+	})
+
+	It("reopens after a failed trial call without emitting another event", func() {
+		newSubject(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond})
+		mockSplitClient.EXPECT().Treatment(key, feature, nil).Return("control").Times(2)
+		subject.BooleanEvaluation(context.Background(), feature, false, evalCtx)
+		Ω(subject.EventChannel()).Should(Receive())
+		time.Sleep(20 * time.Millisecond)
+
+		// act
+		subject.BooleanEvaluation(context.Background(), feature, false, evalCtx)
+
+		Ω(subject.CircuitState()).Should(Equal(CircuitOpen))
+		Ω(subject.EventChannel()).ShouldNot(Receive())
+	})
+})
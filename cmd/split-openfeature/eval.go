@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	splitProvider "github.com/snap-one/fork-split-openfeature-provider-go"
+)
+
+// cliDomain is the OpenFeature domain the command registers its provider under.
+const cliDomain = "split-openfeature-cli"
+
+// resolution is the OpenFeature resolution detail printed by the eval command.
+type resolution struct {
+	Flag         string                   `json:"flag"`
+	Type         string                   `json:"type"`
+	Value        any                      `json:"value"`
+	Variant      string                   `json:"variant"`
+	Reason       openfeature.Reason       `json:"reason"`
+	ErrorCode    openfeature.ErrorCode    `json:"errorCode,omitempty"`
+	ErrorMessage string                   `json:"errorMessage,omitempty"`
+	Config       any                      `json:"config,omitempty"`
+	FlagMetadata openfeature.FlagMetadata `json:"flagMetadata,omitempty"`
+}
+
+// attributeFlags collects repeated -attr name=value flags.
+type attributeFlags map[string]any
+
+func (attrs attributeFlags) String() string {
+	return fmt.Sprint(map[string]any(attrs))
+}
+
+// Set parses a name=value pair. Values that are valid JSON, such as numbers, booleans and lists,
+// keep their JSON type; anything else is a string.
+func (attrs attributeFlags) Set(pair string) error {
+	name, value, ok := strings.Cut(pair, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("attribute %q is not name=value", pair)
+	}
+	var parsed any
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		parsed = value
+	}
+	attrs[name] = parsed
+	return nil
+}
+
+func runEval(args []string, stdout io.Writer, stderr io.Writer) error {
+	var (
+		src          source
+		flagName     string
+		key          string
+		flagType     string
+		defaultValue string
+		attrsJSON    string
+		attrs        = attributeFlags{}
+	)
+	flags := flag.NewFlagSet("eval", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	src.register(flags)
+	flags.StringVar(&flagName, "flag", "", "name of the flag to evaluate (required)")
+	flags.StringVar(&key, "key", "", "targeting key")
+	flags.StringVar(&flagType, "type", "string", "type to evaluate the flag as: bool, string, int, float or object")
+	flags.StringVar(&defaultValue, "default", "", "default value, parsed as -type")
+	flags.StringVar(&attrsJSON, "attrs", "", "attributes as a JSON object")
+	flags.Var(attrs, "attr", "attribute as name=value; may be repeated")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flagName == "" {
+		return errors.New("-flag is required")
+	}
+	if attrsJSON != "" {
+		var fromJSON map[string]any
+		if err := json.Unmarshal([]byte(attrsJSON), &fromJSON); err != nil {
+			return fmt.Errorf("-attrs: %w", err)
+		}
+		for name, value := range fromJSON {
+			if _, ok := attrs[name]; !ok {
+				attrs[name] = value
+			}
+		}
+	}
+
+	provider, release, err := src.provider(stderr)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if err := openfeature.SetNamedProviderAndWait(cliDomain, provider); err != nil {
+		return err
+	}
+	defer openfeature.Shutdown()
+
+	evalCtx := openfeature.NewEvaluationContext(key, attrs)
+	result, err := evaluate(openfeature.NewClient(cliDomain), flagName, flagType, defaultValue, evalCtx)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+func evaluate(client *openfeature.Client, flag string, flagType string, defaultValue string, evalCtx openfeature.EvaluationContext) (resolution, error) {
+	ctx := context.Background()
+	var (
+		value   any
+		details openfeature.EvaluationDetails
+	)
+	// Evaluation errors are part of the resolution detail, so only invalid defaults are returned as errors.
+	switch flagType {
+	case "bool", "boolean":
+		parsed, err := parseDefault(defaultValue, false, strconv.ParseBool)
+		if err != nil {
+			return resolution{}, err
+		}
+		result, _ := client.BooleanValueDetails(ctx, flag, parsed, evalCtx)
+		value, details = result.Value, result.EvaluationDetails
+	case "string":
+		result, _ := client.StringValueDetails(ctx, flag, defaultValue, evalCtx)
+		value, details = result.Value, result.EvaluationDetails
+	case "int", "integer":
+		parsed, err := parseDefault(defaultValue, 0, func(s string) (int64, error) {
+			return strconv.ParseInt(s, 10, 64)
+		})
+		if err != nil {
+			return resolution{}, err
+		}
+		result, _ := client.IntValueDetails(ctx, flag, parsed, evalCtx)
+		value, details = result.Value, result.EvaluationDetails
+	case "float", "number":
+		parsed, err := parseDefault(defaultValue, 0, func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 64)
+		})
+		if err != nil {
+			return resolution{}, err
+		}
+		result, _ := client.FloatValueDetails(ctx, flag, parsed, evalCtx)
+		value, details = result.Value, result.EvaluationDetails
+	case "object", "json":
+		parsed, err := parseDefault[any](defaultValue, nil, func(s string) (any, error) {
+			var object any
+			err := json.Unmarshal([]byte(s), &object)
+			return object, err
+		})
+		if err != nil {
+			return resolution{}, err
+		}
+		result, _ := client.ObjectValueDetails(ctx, flag, parsed, evalCtx)
+		value, details = result.Value, result.EvaluationDetails
+	default:
+		return resolution{}, fmt.Errorf("unknown -type %q", flagType)
+	}
+	return resolution{
+		Flag:         flag,
+		Type:         details.FlagType.String(),
+		Value:        value,
+		Variant:      details.Variant,
+		Reason:       details.Reason,
+		ErrorCode:    details.ErrorCode,
+		ErrorMessage: details.ErrorMessage,
+		Config:       config(details.FlagMetadata),
+		FlagMetadata: details.FlagMetadata,
+	}, nil
+}
+
+func parseDefault[T any](value string, zero T, parse func(string) (T, error)) (T, error) {
+	if value == "" {
+		return zero, nil
+	}
+	parsed, err := parse(value)
+	if err != nil {
+		return zero, fmt.Errorf("-default: %w", err)
+	}
+	return parsed, nil
+}
+
+// config returns the treatment config from the flag metadata, decoded when it is JSON.
+func config(metadata openfeature.FlagMetadata) any {
+	raw, ok := metadata[splitProvider.MetadataConfigKey].(string)
+	if !ok {
+		return nil
+	}
+	var decoded any
+	if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+		return raw
+	}
+	return decoded
+}
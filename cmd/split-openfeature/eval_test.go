@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	splitProvider "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/splitio/go-client/splitio/client"
+)
+
+const splitFile = `
+- checkout:
+    treatment: "on"
+    keys: "user-1"
+    config: '{"color":"red"}'
+- checkout:
+    treatment: "off"
+- limit:
+    treatment: "25"
+`
+
+var _ = Describe("eval", func() {
+	var (
+		dir    string
+		stdout *bytes.Buffer
+		stderr *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+		Ω(os.WriteFile(filepath.Join(dir, "splits.yaml"), []byte(splitFile), 0o600)).Should(Succeed())
+	})
+
+	evalJSON := func(args ...string) map[string]any {
+		code := run(append([]string{"eval"}, args...), stdout, stderr)
+		Ω(code).Should(Equal(0), stderr.String())
+		var result map[string]any
+		Ω(json.Unmarshal(stdout.Bytes(), &result)).Should(Succeed())
+		return result
+	}
+
+	It("prints the resolution detail with the treatment config", func() {
+		result := evalJSON("-split-file", filepath.Join(dir, "splits.yaml"),
+			"-flag", "checkout", "-key", "user-1", "-type", "bool", "-attr", "plan=pro", "-attrs", `{"seats":3}`)
+
+		Ω(result).Should(Equal(map[string]any{
+			"flag":    "checkout",
+			"type":    "bool",
+			"value":   true,
+			"variant": "on",
+			"reason":  "TARGETING_MATCH",
+			"config":  map[string]any{"color": "red"},
+			"flagMetadata": map[string]any{
+				"config": `{"color":"red"}`,
+			},
+		}))
+	})
+
+	It("prints resolution errors with the default value", func() {
+		result := evalJSON("-split-file", filepath.Join(dir, "splits.yaml"),
+			"-flag", "limit", "-key", "user-2", "-type", "bool", "-default", "true")
+
+		Ω(result).Should(HaveKeyWithValue("value", true))
+		Ω(result).Should(HaveKeyWithValue("variant", "25"))
+		Ω(result).Should(HaveKeyWithValue("errorCode", "PARSE_ERROR"))
+	})
+
+	It("evaluates from a snapshot", func() {
+		path := filepath.Join(dir, "snapshot.json")
+		data, err := json.Marshal(splitProvider.Snapshot{
+			SavedAt: time.Now(),
+			Splits: []splitProvider.SnapshotSplit{{
+				SplitView:   client.SplitView{Name: "checkout", Treatments: []string{"on", "off"}},
+				Assignments: map[string]string{"user-1": "off"},
+			}},
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(os.WriteFile(path, data, 0o600)).Should(Succeed())
+
+		result := evalJSON("-snapshot", path, "-flag", "checkout", "-key", "user-1", "-type", "string")
+
+		Ω(result).Should(HaveKeyWithValue("value", "off"))
+		Ω(result).Should(HaveKeyWithValue("reason", "STALE"))
+		Ω(result).Should(HaveKeyWithValue("flagMetadata", map[string]any{"source": "snapshot"}))
+	})
+
+	DescribeTable("rejects invalid arguments",
+		func(args ...string) {
+			code := run(append([]string{"eval"}, args...), stdout, stderr)
+
+			Ω(code).ShouldNot(Equal(0))
+			Ω(stderr.String()).ShouldNot(BeEmpty())
+		},
+		Entry("missing flag", "-split-file", "splits.yaml"),
+		Entry("missing source", "-flag", "checkout", "-api-key", ""),
+		Entry("malformed attribute", "-flag", "checkout", "-attr", "plan"),
+		Entry("unknown type", "-flag", "checkout", "-type", "date", "-snapshot", "missing.json"),
+	)
+
+	It("rejects unknown commands", func() {
+		Ω(run([]string{"frobnicate"}, stdout, stderr)).Should(Equal(2))
+		Ω(run(nil, stdout, stderr)).Should(Equal(2))
+	})
+})
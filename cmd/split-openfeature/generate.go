@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// manifest declares the flags to generate typed accessors for. JSON manifests are read as YAML.
+type manifest struct {
+	Flags []manifestFlag `yaml:"flags"`
+}
+
+type manifestFlag struct {
+	Name        string `yaml:"name"`
+	Type        string `yaml:"type"`
+	Default     any    `yaml:"default"`
+	Description string `yaml:"description"`
+}
+
+// accessorType describes how a manifest type maps to the OpenFeature client.
+type accessorType struct {
+	GoType      string
+	Method      string
+	DetailsType string
+}
+
+var accessorTypes = map[string]accessorType{
+	"bool":   {GoType: "bool", Method: "Boolean", DetailsType: "BooleanEvaluationDetails"},
+	"string": {GoType: "string", Method: "String", DetailsType: "StringEvaluationDetails"},
+	"int":    {GoType: "int64", Method: "Int", DetailsType: "IntEvaluationDetails"},
+	"float":  {GoType: "float64", Method: "Float", DetailsType: "FloatEvaluationDetails"},
+	"object": {GoType: "any", Method: "Object", DetailsType: "InterfaceEvaluationDetails"},
+}
+
+// accessor is a manifest flag ready to be rendered.
+type accessor struct {
+	accessorType
+	Ident       string
+	Key         string
+	Default     string
+	Description string
+}
+
+func runGenerate(args []string, stdout io.Writer, stderr io.Writer) error {
+	var (
+		manifestPath string
+		packageName  string
+		outputPath   string
+	)
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: split-openfeature generate -manifest FILE [-package NAME] [-out FILE]")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&manifestPath, "manifest", "", "YAML or JSON flag manifest (required)")
+	flags.StringVar(&packageName, "package", os.Getenv("GOPACKAGE"), "package of the generated file (defaults to $GOPACKAGE, set by go generate)")
+	flags.StringVar(&outputPath, "out", "", "file to write (defaults to stdout)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if manifestPath == "" {
+		return errors.New("-manifest is required")
+	}
+	if packageName == "" {
+		return errors.New("-package is required outside go generate")
+	}
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return err
+	}
+	source, err := generateAccessors(data, filepath.Base(manifestPath), packageName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", manifestPath, err)
+	}
+	if outputPath == "" {
+		_, err = stdout.Write(source)
+		return err
+	}
+	return os.WriteFile(outputPath, source, 0o644)
+}
+
+// generateAccessors renders the Go source of typed accessors for the flags in the manifest.
+func generateAccessors(data []byte, manifestName string, packageName string) ([]byte, error) {
+	var flags manifest
+	if err := yaml.Unmarshal(data, &flags); err != nil {
+		return nil, err
+	}
+	accessors := make([]accessor, 0, len(flags.Flags))
+	idents := map[string]string{}
+	for _, flag := range flags.Flags {
+		if flag.Name == "" {
+			return nil, errors.New("flag without a name")
+		}
+		kind, ok := accessorTypes[flag.Type]
+		if !ok {
+			return nil, fmt.Errorf("flag %q: unknown type %q", flag.Name, flag.Type)
+		}
+		ident := exportedIdent(flag.Name)
+		// Each flag declares its accessor, its Details accessor and its key constant.
+		generated := []string{ident, ident + "Details", ident + "Key"}
+		for _, name := range generated {
+			if other, ok := idents[name]; ok {
+				return nil, fmt.Errorf("flags %q and %q both generate %s", other, flag.Name, name)
+			}
+		}
+		for _, name := range generated {
+			idents[name] = flag.Name
+		}
+		defaultValue, err := defaultLiteral(flag.Type, flag.Default)
+		if err != nil {
+			return nil, fmt.Errorf("flag %q: %w", flag.Name, err)
+		}
+		accessors = append(accessors, accessor{
+			accessorType: kind,
+			Ident:        ident,
+			Key:          strconv.Quote(flag.Name),
+			Default:      defaultValue,
+			Description:  strings.Join(strings.Fields(flag.Description), " "),
+		})
+	}
+	var source bytes.Buffer
+	err := accessorsTemplate.Execute(&source, map[string]any{
+		"Manifest":  manifestName,
+		"Package":   packageName,
+		"Accessors": accessors,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(source.Bytes())
+}
+
+// exportedIdent turns a flag name such as "checkout-redesign" or "checkout.redesign_v2" into an exported
+// Go identifier such as CheckoutRedesign or CheckoutRedesignV2.
+func exportedIdent(name string) string {
+	var ident strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		ident.WriteRune(r)
+	}
+	if !token.IsExported(ident.String()) {
+		// Names starting with a digit or an uncased letter need a prefix to be exported.
+		return "Flag" + ident.String()
+	}
+	return ident.String()
+}
+
+// defaultLiteral returns the Go expression for a manifest default, checking it matches the flag type.
+func defaultLiteral(flagType string, value any) (string, error) {
+	switch flagType {
+	case "bool":
+		if value == nil {
+			return "false", nil
+		}
+		if b, ok := value.(bool); ok {
+			return strconv.FormatBool(b), nil
+		}
+	case "string":
+		if value == nil {
+			return `""`, nil
+		}
+		if s, ok := value.(string); ok {
+			return strconv.Quote(s), nil
+		}
+	case "int":
+		if value == nil {
+			return "0", nil
+		}
+		if i, ok := value.(int); ok {
+			return strconv.Itoa(i), nil
+		}
+	case "float":
+		if value == nil {
+			return "0", nil
+		}
+		switch f := value.(type) {
+		case int:
+			return strconv.Itoa(f), nil
+		case float64:
+			return strconv.FormatFloat(f, 'g', -1, 64), nil
+		}
+	case "object":
+		if value == nil {
+			return "nil", nil
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("default is not JSON: %w", err)
+		}
+		return fmt.Sprintf("decodeDefault(%s)", strconv.Quote(string(encoded))), nil
+	}
+	return "", fmt.Errorf("default %v is not of type %s", value, flagType)
+}
+
+var accessorsTemplate = template.Must(template.New("accessors").Parse(`// Code generated by split-openfeature generate from {{.Manifest}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+{{- range .Accessors}}{{if eq .Method "Object"}}
+	"encoding/json"
+{{- break}}{{end}}{{end}}
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// Flag keys declared in {{.Manifest}}.
+const (
+{{- range .Accessors}}
+	{{.Ident}}Key = {{.Key}}
+{{- end}}
+)
+
+// Flags evaluates the flags declared in {{.Manifest}} with their declared types and defaults.
+type Flags struct {
+	client openfeature.IClient
+}
+
+// New returns Flags evaluating through the given OpenFeature client.
+func New(client openfeature.IClient) *Flags {
+	return &Flags{client: client}
+}
+{{range .Accessors}}
+// {{.Ident}} evaluates {{.Key}}{{if .Description}}: {{.Description}}{{else}}.{{end}}
+func (f *Flags) {{.Ident}}(ctx context.Context, evalCtx openfeature.EvaluationContext) {{.GoType}} {
+	return f.client.{{.Method}}(ctx, {{.Key}}, {{.Default}}, evalCtx)
+}
+
+// {{.Ident}}Details evaluates {{.Key}} and returns the evaluation details.
+func (f *Flags) {{.Ident}}Details(ctx context.Context, evalCtx openfeature.EvaluationContext) (openfeature.{{.DetailsType}}, error) {
+	return f.client.{{.Method}}ValueDetails(ctx, {{.Key}}, {{.Default}}, evalCtx)
+}
+{{end}}
+{{- range .Accessors}}{{if eq .Method "Object"}}
+// decodeDefault decodes an object default from the manifest.
+func decodeDefault(encoded string) any {
+	var value any
+	if err := json.Unmarshal([]byte(encoded), &value); err != nil {
+		panic(err)
+	}
+	return value
+}
+{{- break}}{{end}}{{end}}
+`))
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("generate", func() {
+	var (
+		dir    string
+		stdout *bytes.Buffer
+		stderr *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+	})
+
+	generate := func(manifest string, args ...string) int {
+		path := filepath.Join(dir, "flags.yaml")
+		Ω(os.WriteFile(path, []byte(manifest), 0o600)).Should(Succeed())
+		return run(append([]string{"generate", "-manifest", path, "-package", "flags"}, args...), stdout, stderr)
+	}
+
+	It("writes the accessors of the manifest flags", func() {
+		out := filepath.Join(dir, "flags_gen.go")
+
+		code := run([]string{"generate", "-manifest", filepath.Join("testdata", "flags.yaml"), "-package", "flags", "-out", out}, stdout, stderr)
+
+		Ω(code).Should(Equal(0), stderr.String())
+		golden, err := os.ReadFile(filepath.Join("testdata", "flags_gen.go.golden"))
+		Ω(err).ShouldNot(HaveOccurred())
+		generated, err := os.ReadFile(out)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(generated)).Should(Equal(string(golden)))
+	})
+
+	It("reads JSON manifests and defaults the package to $GOPACKAGE", func() {
+		GinkgoT().Setenv("GOPACKAGE", "features")
+		path := filepath.Join(dir, "flags.json")
+		Ω(os.WriteFile(path, []byte(`{"flags":[{"name":"2fa","type":"bool","default":true}]}`), 0o600)).Should(Succeed())
+
+		code := run([]string{"generate", "-manifest", path}, stdout, stderr)
+
+		Ω(code).Should(Equal(0), stderr.String())
+		Ω(stdout.String()).Should(ContainSubstring("package features\n"))
+		Ω(stdout.String()).Should(ContainSubstring(`func (f *Flags) Flag2fa(ctx context.Context, evalCtx openfeature.EvaluationContext) bool {
+	return f.client.Boolean(ctx, "2fa", true, evalCtx)
+}`))
+		Ω(stdout.String()).ShouldNot(ContainSubstring("encoding/json"))
+	})
+
+	DescribeTable("rejects invalid manifests",
+		func(manifest string, message string) {
+			Ω(generate(manifest)).Should(Equal(1))
+			Ω(stderr.String()).Should(ContainSubstring(message))
+		},
+		Entry("unknown type", "flags:\n  - name: checkout\n    type: boolean\n", `flag "checkout": unknown type "boolean"`),
+		Entry("mismatched default", "flags:\n  - name: limit\n    type: int\n    default: ten\n", `flag "limit": default ten is not of type int`),
+		Entry("colliding identifiers", "flags:\n  - name: new-checkout\n    type: bool\n  - name: new_checkout\n    type: bool\n",
+			`flags "new-checkout" and "new_checkout" both generate NewCheckout`),
+		Entry("identifiers colliding with generated ones", "flags:\n  - name: checkout\n    type: bool\n  - name: checkout-details\n    type: bool\n",
+			`flags "checkout" and "checkout-details" both generate CheckoutDetails`),
+		Entry("missing name", "flags:\n  - type: bool\n", "flag without a name"),
+	)
+
+	It("requires a manifest", func() {
+		Ω(run([]string{"generate", "-package", "flags"}, stdout, stderr)).Should(Equal(1))
+		Ω(stderr.String()).Should(ContainSubstring("-manifest is required"))
+	})
+})
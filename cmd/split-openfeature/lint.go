@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	splitProvider "github.com/snap-one/fork-split-openfeature-provider-go"
+)
+
+func runLint(args []string, stdout io.Writer, stderr io.Writer) error {
+	var (
+		src          source
+		includeTests bool
+		reportStale  bool
+	)
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: split-openfeature lint [flags] [dir ...]")
+		flags.PrintDefaults()
+	}
+	src.register(flags)
+	flags.BoolVar(&includeTests, "tests", false, "also scan _test.go files")
+	flags.BoolVar(&reportStale, "stale", true, "report flags defined in Split that are not referenced in source")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	roots := flags.Args()
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+
+	references, err := scanFlagReferences(roots, includeTests)
+	if err != nil {
+		return err
+	}
+	provider, release, err := src.provider(stderr)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	var expected []splitProvider.ExpectedFlag
+	seen := map[splitProvider.ExpectedFlag]bool{}
+	for _, reference := range references {
+		want := splitProvider.ExpectedFlag{Name: reference.Flag, Type: reference.Type}
+		if !seen[want] {
+			seen[want] = true
+			expected = append(expected, want)
+		}
+	}
+	report, err := provider.ValidateFlags(expected...)
+	if err != nil {
+		return err
+	}
+	problems := map[splitProvider.ExpectedFlag]splitProvider.FlagProblem{}
+	for _, problem := range report.Problems {
+		problems[splitProvider.ExpectedFlag{Name: problem.Flag, Type: problem.Type}] = problem
+	}
+
+	count := 0
+	referenced := map[string]bool{}
+	for _, reference := range references {
+		referenced[reference.Flag] = true
+		if problem, ok := problems[splitProvider.ExpectedFlag{Name: reference.Flag, Type: reference.Type}]; ok {
+			fmt.Fprintf(stdout, "%s: %s\n", reference.Position, problem)
+			count++
+		}
+	}
+	if reportStale {
+		names, err := provider.FlagNames()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if !referenced[name] {
+				fmt.Fprintf(stdout, "flag %q is not referenced in source\n", name)
+				count++
+			}
+		}
+	}
+	if count > 0 {
+		return fmt.Errorf("%d problem(s) in %d flag references", count, len(references))
+	}
+	return nil
+}
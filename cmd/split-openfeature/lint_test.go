@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const lintSource = `package app
+
+import (
+	"context"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+const dynamic = "limit"
+
+func evaluate(ctx context.Context, client *openfeature.Client) {
+	client.BooleanValue(ctx, "checkout", false, openfeature.EvaluationContext{})
+	client.IntValueDetails(ctx, "checkout", 0, openfeature.EvaluationContext{})
+	client.StringValue(ctx, "chekout", "", openfeature.EvaluationContext{})
+	client.Int(ctx, dynamic, 0, openfeature.EvaluationContext{})
+}
+`
+
+const lintTestSource = `package app
+
+import (
+	"context"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func evaluateInTest(ctx context.Context, client *openfeature.Client) {
+	client.Int(ctx, "limit", 0, openfeature.EvaluationContext{})
+}
+`
+
+var _ = Describe("lint", func() {
+	var (
+		dir       string
+		sourceDir string
+		stdout    *bytes.Buffer
+		stderr    *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		sourceDir = filepath.Join(dir, "app")
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+		Ω(os.WriteFile(filepath.Join(dir, "splits.yaml"), []byte(splitFile), 0o600)).Should(Succeed())
+		Ω(os.MkdirAll(filepath.Join(sourceDir, "vendor"), 0o700)).Should(Succeed())
+		Ω(os.WriteFile(filepath.Join(sourceDir, "app.go"), []byte(lintSource), 0o600)).Should(Succeed())
+		Ω(os.WriteFile(filepath.Join(sourceDir, "app_test.go"), []byte(lintTestSource), 0o600)).Should(Succeed())
+		Ω(os.WriteFile(filepath.Join(sourceDir, "vendor", "dep.go"), []byte(strings.ReplaceAll(lintTestSource, "limit", "vendored")), 0o600)).Should(Succeed())
+	})
+
+	lines := func() []string {
+		return strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	}
+
+	It("reports missing, type-incompatible and stale flags", func() {
+		code := run([]string{"lint", "-split-file", filepath.Join(dir, "splits.yaml"), sourceDir}, stdout, stderr)
+
+		Ω(code).Should(Equal(1))
+		appFile := filepath.Join(sourceDir, "app.go")
+		Ω(lines()).Should(Equal([]string{
+			appFile + `:13:30: flag "checkout" has treatments that are not int: on, off`,
+			appFile + `:14:26: flag "chekout" does not exist`,
+			`flag "limit" is not referenced in source`,
+		}))
+		Ω(stderr.String()).Should(ContainSubstring("3 problem(s) in 3 flag references"))
+	})
+
+	It("scans test files and skips stale flags on request", func() {
+		code := run([]string{"lint", "-split-file", filepath.Join(dir, "splits.yaml"), "-tests", "-stale=false", sourceDir}, stdout, stderr)
+
+		Ω(code).Should(Equal(1))
+		Ω(lines()).Should(HaveLen(2))
+		Ω(stdout.String()).ShouldNot(ContainSubstring("limit"))
+		Ω(stdout.String()).ShouldNot(ContainSubstring("vendored"))
+	})
+
+	It("succeeds when every flag is valid", func() {
+		Ω(os.WriteFile(filepath.Join(sourceDir, "app.go"), []byte(`package app
+
+import (
+	"context"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func evaluate(ctx context.Context, client *openfeature.Client) {
+	client.BooleanValue(ctx, "checkout", false, openfeature.EvaluationContext{})
+	client.Int(ctx, "limit", 0, openfeature.EvaluationContext{})
+}
+`), 0o600)).Should(Succeed())
+
+		code := run([]string{"lint", "-split-file", filepath.Join(dir, "splits.yaml"), sourceDir}, stdout, stderr)
+
+		Ω(code).Should(Equal(0), stderr.String())
+		Ω(stdout.String()).Should(BeEmpty())
+	})
+
+	It("fails on source that does not parse", func() {
+		Ω(os.WriteFile(filepath.Join(sourceDir, "broken.go"), []byte("package app\nfunc {"), 0o600)).Should(Succeed())
+
+		code := run([]string{"lint", "-split-file", filepath.Join(dir, "splits.yaml"), sourceDir}, stdout, stderr)
+
+		Ω(code).Should(Equal(1))
+		Ω(stderr.String()).Should(ContainSubstring("broken.go"))
+	})
+})
@@ -0,0 +1,65 @@
+// Command split-openfeature evaluates flags through the Split OpenFeature provider, to answer questions like
+// "why does user X see variant Y" without writing a throwaway program.
+//
+// Usage:
+//
+//	split-openfeature eval [source flags] -flag NAME -key KEY [-type bool|string|int|float|object] [-attr name=value]... [-attrs JSON]
+//	split-openfeature lint [source flags] [-tests] [-stale=false] [dir ...]
+//	split-openfeature generate -manifest FILE [-package NAME] [-out FILE]
+//
+// The lint command scans Go source for OpenFeature evaluations with literal flag keys and reports flags missing
+// from Split, flags whose treatments do not parse as the requested type, and Split flags no longer referenced.
+//
+// The generate command turns a YAML or JSON manifest of flags into typed Go accessors, and is meant to be run from
+// a go:generate directive.
+//
+// The eval and lint commands build the provider from exactly one source: -api-key (or SPLIT_API_KEY), a localhost
+// -split-file, or a -snapshot.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage: split-openfeature <command> [flags]
+
+commands:
+  eval      evaluate a flag and print the OpenFeature resolution detail
+  lint      check flags referenced in Go source against Split
+  generate  write typed Go accessors for the flags in a manifest
+
+Run "split-openfeature <command> -h" for the flags of a command.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	var err error
+	switch args[0] {
+	case "eval":
+		err = runEval(args[1:], stdout, stderr)
+	case "lint":
+		err = runLint(args[1:], stdout, stderr)
+	case "generate":
+		err = runGenerate(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "split-openfeature %s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
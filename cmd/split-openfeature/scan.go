@@ -0,0 +1,120 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// evaluationMethods maps the OpenFeature client methods that evaluate a flag to the type they request.
+var evaluationMethods = map[string]openfeature.Type{
+	"Boolean":             openfeature.Boolean,
+	"BooleanValue":        openfeature.Boolean,
+	"BooleanValueDetails": openfeature.Boolean,
+	"String":              openfeature.String,
+	"StringValue":         openfeature.String,
+	"StringValueDetails":  openfeature.String,
+	"Int":                 openfeature.Int,
+	"IntValue":            openfeature.Int,
+	"IntValueDetails":     openfeature.Int,
+	"Float":               openfeature.Float,
+	"FloatValue":          openfeature.Float,
+	"FloatValueDetails":   openfeature.Float,
+	"Object":              openfeature.Object,
+	"ObjectValue":         openfeature.Object,
+	"ObjectValueDetails":  openfeature.Object,
+}
+
+// flagReference is a flag evaluated in source with a literal key.
+type flagReference struct {
+	Flag     string
+	Type     openfeature.Type
+	Position token.Position
+}
+
+// scanFlagReferences parses the Go files under roots and returns the flag evaluations whose key is a
+// string literal, sorted by position. Test files are only scanned when includeTests is set.
+func scanFlagReferences(roots []string, includeTests bool) ([]flagReference, error) {
+	var references []flagReference
+	fset := token.NewFileSet()
+	for _, root := range roots {
+		// Directories are always scanned recursively, so accept package patterns like ./... too.
+		root = strings.TrimSuffix(root, "...")
+		if root == "" {
+			root = "."
+		}
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				name := entry.Name()
+				if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") || (!includeTests && strings.HasSuffix(path, "_test.go")) {
+				return nil
+			}
+			file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+			if err != nil {
+				return err
+			}
+			references = append(references, fileFlagReferences(fset, file)...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(references, func(i, j int) bool {
+		a, b := references[i].Position, references[j].Position
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return references, nil
+}
+
+func fileFlagReferences(fset *token.FileSet, file *ast.File) []flagReference {
+	var references []flagReference
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		flagType, ok := evaluationMethods[selector.Sel.Name]
+		// Every evaluation method takes a context, the flag key, a default value and an evaluation context.
+		if !ok || len(call.Args) < 4 {
+			return true
+		}
+		literal, ok := call.Args[1].(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return true
+		}
+		flag, err := strconv.Unquote(literal.Value)
+		if err != nil {
+			return true
+		}
+		references = append(references, flagReference{
+			Flag:     flag,
+			Type:     flagType,
+			Position: fset.Position(literal.Pos()),
+		})
+		return true
+	})
+	return references
+}
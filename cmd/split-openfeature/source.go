@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	splitProvider "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/splitio/go-client/splitio/client"
+	"github.com/splitio/go-client/splitio/conf"
+	"github.com/splitio/go-toolkit/logging"
+)
+
+// source selects where the provider gets its split definitions from.
+type source struct {
+	apiKey    string
+	splitFile string
+	snapshot  string
+	timeout   int
+}
+
+func (src *source) register(flags *flag.FlagSet) {
+	flags.StringVar(&src.apiKey, "api-key", os.Getenv("SPLIT_API_KEY"), "Split SDK API key (defaults to $SPLIT_API_KEY)")
+	flags.StringVar(&src.splitFile, "split-file", "", "Split localhost mode file (.yaml, .yml or classic format)")
+	flags.StringVar(&src.snapshot, "snapshot", "", "snapshot written by the provider's WithSnapshot option")
+	flags.IntVar(&src.timeout, "timeout", 10, "seconds to wait for the Split SDK to be ready")
+}
+
+// provider builds the provider for the selected source. The returned function releases the Split SDK.
+func (src *source) provider(stderr io.Writer) (*splitProvider.SplitProvider, func(), error) {
+	switch {
+	case src.splitFile != "" && src.snapshot != "":
+		return nil, nil, errors.New("use only one of -split-file and -snapshot")
+	case src.snapshot != "":
+		return src.snapshotProvider()
+	case src.splitFile != "":
+		return src.sdkProvider("localhost", stderr)
+	case src.apiKey != "":
+		return src.sdkProvider(src.apiKey, stderr)
+	default:
+		return nil, nil, errors.New("one of -api-key, -split-file or -snapshot is required")
+	}
+}
+
+func (src *source) sdkProvider(apiKey string, stderr io.Writer) (*splitProvider.SplitProvider, func(), error) {
+	cfg := conf.Default()
+	cfg.LoggerConfig = logging.LoggerOptions{
+		LogLevel:      logging.LevelError,
+		ErrorWriter:   stderr,
+		WarningWriter: stderr,
+		InfoWriter:    io.Discard,
+		DebugWriter:   io.Discard,
+		VerboseWriter: io.Discard,
+	}
+	if src.splitFile != "" {
+		cfg.SplitFile = src.splitFile
+	}
+	factory, err := client.NewSplitFactory(apiKey, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	splitClient := factory.Client()
+	if err := splitClient.BlockUntilReady(src.timeout); err != nil {
+		factory.Destroy()
+		return nil, nil, err
+	}
+	provider, err := splitProvider.NewProvider(splitClient,
+		splitProvider.WithSplitFactory(factory),
+		splitProvider.WithSplitManager(factory.Manager()))
+	if err != nil {
+		factory.Destroy()
+		return nil, nil, err
+	}
+	return provider, factory.Destroy, nil
+}
+
+func (src *source) snapshotProvider() (*splitProvider.SplitProvider, func(), error) {
+	snapshot, err := splitProvider.ReadSnapshot(src.snapshot)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(snapshot.Splits) == 0 {
+		return nil, nil, fmt.Errorf("snapshot %s has no splits", src.snapshot)
+	}
+	provider, err := splitProvider.NewProvider(offlineClient{},
+		splitProvider.WithSplitFactory(offlineClient{}),
+		splitProvider.WithSnapshot(src.snapshot, 0))
+	if err != nil {
+		return nil, nil, err
+	}
+	return provider, func() {}, nil
+}
+
+// offlineClient is a Split client and factory that never becomes ready, so that the provider serves
+// every treatment from its snapshot.
+type offlineClient struct{}
+
+func (offlineClient) Treatment(any, string, map[string]any) string {
+	return "control"
+}
+
+func (offlineClient) IsReady() bool {
+	return false
+}
+
+func (offlineClient) BlockUntilReady(int) error {
+	return errors.New("offline")
+}
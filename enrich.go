@@ -0,0 +1,60 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"context"
+	"errors"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// Enricher returns attributes to add to the evaluation context of an evaluation, such as the environment,
+// region, app version or values carried by ctx. It may return nil to add nothing.
+type Enricher func(ctx context.Context, hookContext openfeature.HookContext) map[string]any
+
+// StaticEnricher returns an Enricher adding the same attributes to every evaluation.
+func StaticEnricher(attributes map[string]any) Enricher {
+	return func(context.Context, openfeature.HookContext) map[string]any {
+		return attributes
+	}
+}
+
+// WithEnrichers adds the attributes of the enrichers to the context of every evaluation made through an
+// OpenFeature client, by returning an enrichment hook from Hooks.
+func WithEnrichers(enrichers ...Enricher) Option {
+	return func(provider *SplitProvider) error {
+		for _, enricher := range enrichers {
+			if enricher == nil {
+				return errors.New("enrichers must not be nil")
+			}
+		}
+		provider.hooks = append(provider.hooks, NewEnrichmentHook(enrichers...))
+		return nil
+	}
+}
+
+// NewEnrichmentHook returns a Before hook merging the attributes of the enrichers into the evaluation context,
+// for use with any OpenFeature client or provider. Enrichers are applied in order, so later ones override earlier
+// ones, and attributes already in the evaluation context take precedence over all of them.
+func NewEnrichmentHook(enrichers ...Enricher) openfeature.Hook {
+	return enrichmentHook{enrichers: enrichers}
+}
+
+type enrichmentHook struct {
+	openfeature.UnimplementedHook
+	enrichers []Enricher
+}
+
+func (hook enrichmentHook) Before(ctx context.Context, hookContext openfeature.HookContext, _ openfeature.HookHints) (*openfeature.EvaluationContext, error) {
+	evalCtx := hookContext.EvaluationContext()
+	attributes := map[string]any{}
+	for _, enricher := range hook.enrichers {
+		for key, value := range enricher(ctx, hookContext) {
+			attributes[key] = value
+		}
+	}
+	for key, value := range evalCtx.Attributes() {
+		attributes[key] = value
+	}
+	enriched := openfeature.NewEvaluationContext(evalCtx.TargetingKey(), attributes)
+	return &enriched, nil
+}
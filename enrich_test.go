@@ -0,0 +1,73 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+type regionKey struct{}
+
+var _ = Describe("Context enrichment", func() {
+	var (
+		mockSplitClient *mocks.MockSplitClient
+		client          *openfeature.Client
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		subject, err := NewProvider(mockSplitClient, WithEnrichers(
+			StaticEnricher(map[string]any{"environment": "production", "appVersion": "1.2.3"}),
+			func(ctx context.Context, _ openfeature.HookContext) map[string]any {
+				if region, ok := ctx.Value(regionKey{}).(string); ok {
+					return map[string]any{"region": region}
+				}
+				return nil
+			},
+		))
+		Ω(err).ShouldNot(HaveOccurred())
+		domain := uuid.NewString()
+		Ω(openfeature.SetNamedProviderAndWait(domain, subject)).Should(Succeed())
+		client = openfeature.NewClient(domain)
+	})
+
+	It("rejects nil enrichers", func() {
+		_, err := NewProvider(mockSplitClient, WithEnrichers(nil))
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("adds static and dynamic attributes to every evaluation", func() {
+		mockSplitClient.EXPECT().
+			Treatment("user-1", "checkout", map[string]any{
+				"environment": "production",
+				"appVersion":  "1.2.3",
+				"region":      "eu-west-1",
+				"plan":        "pro",
+			}).
+			Return("on")
+		ctx := context.WithValue(context.Background(), regionKey{}, "eu-west-1")
+
+		value, err := client.BooleanValue(ctx, "checkout", false, openfeature.NewEvaluationContext("user-1", map[string]any{"plan": "pro"}))
+
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(value).Should(BeTrue())
+	})
+
+	It("keeps the attributes already in the context", func() {
+		mockSplitClient.EXPECT().
+			Treatment("user-1", "checkout", map[string]any{"environment": "staging", "appVersion": "1.2.3"}).
+			Return("off")
+
+		value, err := client.BooleanValue(context.Background(), "checkout", true, openfeature.NewEvaluationContext("user-1", map[string]any{"environment": "staging"}))
+
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(value).Should(BeFalse())
+	})
+})
@@ -0,0 +1,100 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"gopkg.in/yaml.v3"
+)
+
+// FallbackTreatment is the treatment, and optional dynamic configuration, served for a flag
+// when the Split client cannot evaluate it.
+type FallbackTreatment struct {
+	Treatment string `json:"treatment" yaml:"treatment"`
+	Config    string `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// UnmarshalJSON accepts either a bare treatment string or an object with treatment and config.
+func (fallback *FallbackTreatment) UnmarshalJSON(data []byte) error {
+	var treatment string
+	if err := json.Unmarshal(data, &treatment); err == nil {
+		*fallback = FallbackTreatment{Treatment: treatment}
+		return nil
+	}
+	type plain FallbackTreatment
+	return json.Unmarshal(data, (*plain)(fallback))
+}
+
+// UnmarshalYAML accepts either a bare treatment string or a mapping with treatment and config.
+func (fallback *FallbackTreatment) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*fallback = FallbackTreatment{Treatment: node.Value}
+		return nil
+	}
+	type plain FallbackTreatment
+	return node.Decode((*plain)(fallback))
+}
+
+// WithFallbackTreatments serves the given treatments, keyed by flag name, whenever the Split client
+// returns control. Such results are reported with reason DEFAULT and FlagMetadata source "fallback".
+func WithFallbackTreatments(treatments map[string]FallbackTreatment) Option {
+	return func(provider *SplitProvider) error {
+		provider.fallback = treatments
+		return nil
+	}
+}
+
+// WithFallbackFile loads fallback treatments from a JSON or YAML file (chosen by its .json, .yaml or .yml
+// extension) mapping flag names to a treatment string or an object with treatment and config.
+// See WithFallbackTreatments.
+func WithFallbackFile(path string) Option {
+	return func(provider *SplitProvider) error {
+		treatments, err := loadFallbackFile(path)
+		if err != nil {
+			return err
+		}
+		return WithFallbackTreatments(treatments)(provider)
+	}
+}
+
+func loadFallbackFile(path string) (map[string]FallbackTreatment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	treatments := map[string]FallbackTreatment{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &treatments)
+	case ".json":
+		err = json.Unmarshal(data, &treatments)
+	default:
+		return nil, fmt.Errorf("unsupported fallback file extension %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading fallback file %s: %w", path, err)
+	}
+	return treatments, nil
+}
+
+func (provider *SplitProvider) fallbackEvaluation(flag string) (evaluation, bool) {
+	fallback, ok := provider.fallback[flag]
+	if !ok || noTreatment(fallback.Treatment) {
+		return evaluation{}, false
+	}
+	metadata := openfeature.FlagMetadata{
+		MetadataSourceKey: SourceFallback,
+	}
+	if fallback.Config != "" {
+		metadata[MetadataConfigKey] = fallback.Config
+	}
+	return evaluation{
+		treatment: fallback.Treatment,
+		reason:    openfeature.DefaultReason,
+		metadata:  metadata,
+	}, true
+}
@@ -0,0 +1,134 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Fallback treatments", func() {
+	var (
+		mockSplitClient *mocks.MockSplitClient
+		dir             string
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		dir = GinkgoT().TempDir()
+	})
+
+	writeFile := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		Ω(os.WriteFile(path, []byte(content), 0o600)).Should(Succeed())
+		return path
+	}
+
+	DescribeTable("serves the fallback treatment when split returns control",
+		func(name string, content string) {
+			key := uuid.NewString()
+			evalCtx := openfeature.FlattenedContext{
+				openfeature.TargetingKey: key,
+			}
+			subject, err := NewProvider(mockSplitClient, WithFallbackFile(writeFile(name, content)))
+			Ω(err).ShouldNot(HaveOccurred())
+			mockSplitClient.EXPECT().Treatment(key, "checkout", nil).Return("control")
+			mockSplitClient.EXPECT().Treatment(key, "banner", nil).Return("control")
+
+			// act
+			checkout := subject.BooleanEvaluation(context.Background(), "checkout", false, evalCtx)
+			banner := subject.StringEvaluation(context.Background(), "banner", "", evalCtx)
+
+			Ω(checkout).Should(Equal(openfeature.BoolResolutionDetail{
+				Value: true,
+				ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+					Reason:  openfeature.DefaultReason,
+					Variant: "on",
+					FlagMetadata: openfeature.FlagMetadata{
+						MetadataSourceKey: SourceFallback,
+					},
+				},
+			}))
+			Ω(banner).Should(Equal(openfeature.StringResolutionDetail{
+				Value: "blue",
+				ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+					Reason:  openfeature.DefaultReason,
+					Variant: "blue",
+					FlagMetadata: openfeature.FlagMetadata{
+						MetadataSourceKey: SourceFallback,
+						MetadataConfigKey: `{"size":2}`,
+					},
+				},
+			}))
+		},
+		Entry("from json", "fallback.json", `{
+			"checkout": "on",
+			"banner": {"treatment": "blue", "config": "{\"size\":2}"}
+		}`),
+		Entry("from yaml", "fallback.yaml", `
+checkout: "on"
+banner:
+  treatment: blue
+  config: '{"size":2}'
+`),
+	)
+
+	It("prefers the live treatment over the fallback", func() {
+		key := uuid.NewString()
+		subject, err := NewProvider(mockSplitClient, WithFallbackTreatments(map[string]FallbackTreatment{
+			"checkout": {Treatment: "on"},
+		}))
+		Ω(err).ShouldNot(HaveOccurred())
+		mockSplitClient.EXPECT().Treatment(key, "checkout", nil).Return("off")
+
+		// act
+		result := subject.BooleanEvaluation(context.Background(), "checkout", true, openfeature.FlattenedContext{
+			openfeature.TargetingKey: key,
+		})
+
+		Ω(result.Value).Should(BeFalse())
+		Ω(result.Reason).Should(Equal(openfeature.TargetingMatchReason))
+		Ω(result.FlagMetadata).Should(BeNil())
+	})
+
+	It("returns flag not found when there is no fallback for the flag", func() {
+		key := uuid.NewString()
+		feature := uuid.NewString()
+		subject, err := NewProvider(mockSplitClient, WithFallbackTreatments(map[string]FallbackTreatment{
+			"checkout": {Treatment: "on"},
+		}))
+		Ω(err).ShouldNot(HaveOccurred())
+		mockSplitClient.EXPECT().Treatment(key, feature, nil).Return("control")
+
+		// act
+		result := subject.BooleanEvaluation(context.Background(), feature, true, openfeature.FlattenedContext{
+			openfeature.TargetingKey: key,
+		})
+
+		Ω(result.Value).Should(BeTrue())
+		Ω(result.ResolutionError).Should(Equal(openfeature.NewFlagNotFoundResolutionError("Flag not found.")))
+	})
+
+	DescribeTable("fails to create the provider with an invalid file",
+		func(name string, content string) {
+			_, err := NewProvider(mockSplitClient, WithFallbackFile(writeFile(name, content)))
+			Ω(err).Should(HaveOccurred())
+		},
+		Entry("malformed json", "fallback.json", `{"checkout":`),
+		Entry("malformed yaml", "fallback.yml", "checkout: [on"),
+		Entry("unknown extension", "fallback.txt", "checkout=on"),
+	)
+
+	It("fails to create the provider when the file is missing", func() {
+		_, err := NewProvider(mockSplitClient, WithFallbackFile(filepath.Join(dir, "missing.json")))
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,88 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/splitio/go-client/splitio/client"
+)
+
+// ErrNoSplitManager is returned by operations that need the Split manager when none was given with WithSplitManager.
+var ErrNoSplitManager = errors.New("no split manager configured")
+
+// Flag describes a flag defined in Split without depending on the Split SDK types.
+// The Split SDK in use does not report a split's default treatment, so it is not included.
+type Flag struct {
+	Name         string            `json:"name"`
+	TrafficType  string            `json:"trafficType"`
+	Killed       bool              `json:"killed"`
+	Treatments   []string          `json:"treatments"`
+	ChangeNumber int64             `json:"changeNumber"`
+	Configs      map[string]string `json:"configs,omitempty"`
+}
+
+func newFlag(view client.SplitView) Flag {
+	return Flag{
+		Name:         view.Name,
+		TrafficType:  view.TrafficType,
+		Killed:       view.Killed,
+		Treatments:   distinctTreatments(view.Treatments),
+		ChangeNumber: view.ChangeNumber,
+		Configs:      view.Configs,
+	}
+}
+
+// localhostPlaceholderTreatment is the treatment of the empty partition Split localhost mode adds to every rollout.
+const localhostPlaceholderTreatment = "_"
+
+// distinctTreatments removes the repetitions the Split manager reports when a treatment appears in several conditions,
+// and the placeholder treatment of localhost mode, which is never served.
+func distinctTreatments(treatments []string) []string {
+	distinct := make([]string, 0, len(treatments))
+	seen := make(map[string]bool, len(treatments))
+	for _, treatment := range treatments {
+		if !seen[treatment] && treatment != localhostPlaceholderTreatment {
+			seen[treatment] = true
+			distinct = append(distinct, treatment)
+		}
+	}
+	return distinct
+}
+
+// FlagNames returns the sorted names of the flags known to the Split manager.
+func (provider *SplitProvider) FlagNames() ([]string, error) {
+	if provider.manager == nil {
+		return nil, ErrNoSplitManager
+	}
+	names := provider.manager.SplitNames()
+	sort.Strings(names)
+	return names, nil
+}
+
+// Flags returns the flags known to the Split manager, sorted by name.
+func (provider *SplitProvider) Flags() ([]Flag, error) {
+	if provider.manager == nil {
+		return nil, ErrNoSplitManager
+	}
+	views := provider.manager.Splits()
+	flags := make([]Flag, 0, len(views))
+	for _, view := range views {
+		flags = append(flags, newFlag(view))
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Name < flags[j].Name
+	})
+	return flags, nil
+}
+
+// Flag returns the named flag, or false if the Split manager does not know it.
+func (provider *SplitProvider) Flag(name string) (Flag, bool, error) {
+	if provider.manager == nil {
+		return Flag{}, false, ErrNoSplitManager
+	}
+	view := provider.manager.Split(name)
+	if view == nil {
+		return Flag{}, false, nil
+	}
+	return newFlag(*view), true, nil
+}
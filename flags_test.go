@@ -0,0 +1,117 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"github.com/splitio/go-client/splitio/client"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Flags", func() {
+	var (
+		mockSplitClient  *mocks.MockSplitClient
+		mockSplitManager *mocks.MockSplitManager
+		subject          *SplitProvider
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		mockSplitManager = mocks.NewMockSplitManager(mockCtrl)
+		var err error
+		subject, err = NewProvider(mockSplitClient, WithSplitManager(mockSplitManager))
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	Describe("FlagNames", func() {
+		It("returns the sorted split names", func() {
+			mockSplitManager.EXPECT().SplitNames().Return([]string{"checkout", "banner"})
+
+			// act
+			names, err := subject.FlagNames()
+
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(names).Should(Equal([]string{"banner", "checkout"}))
+		})
+	})
+
+	Describe("Flags", func() {
+		It("returns every split as a flag sorted by name", func() {
+			mockSplitManager.EXPECT().Splits().Return([]client.SplitView{
+				{
+					Name:         "checkout",
+					TrafficType:  "user",
+					Treatments:   []string{"on", "off", "on"},
+					ChangeNumber: 12,
+					Configs:      map[string]string{"on": `{"color":"red"}`},
+				},
+				{
+					Name:        "banner",
+					TrafficType: "account",
+					Killed:      true,
+					Treatments:  []string{"blue"},
+				},
+			})
+
+			// act
+			flags, err := subject.Flags()
+
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(flags).Should(Equal([]Flag{
+				{
+					Name:        "banner",
+					TrafficType: "account",
+					Killed:      true,
+					Treatments:  []string{"blue"},
+				},
+				{
+					Name:         "checkout",
+					TrafficType:  "user",
+					Treatments:   []string{"on", "off"},
+					ChangeNumber: 12,
+					Configs:      map[string]string{"on": `{"color":"red"}`},
+				},
+			}))
+		})
+	})
+
+	Describe("Flag", func() {
+		It("returns a single split as a flag", func() {
+			mockSplitManager.EXPECT().Split("checkout").Return(&client.SplitView{
+				Name:       "checkout",
+				Treatments: []string{"on", "off"},
+			})
+
+			// act
+			flag, ok, err := subject.Flag("checkout")
+
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ok).Should(BeTrue())
+			Ω(flag).Should(Equal(Flag{Name: "checkout", Treatments: []string{"on", "off"}}))
+		})
+
+		It("reports an unknown split", func() {
+			mockSplitManager.EXPECT().Split("missing").Return(nil)
+
+			// act
+			_, ok, err := subject.Flag("missing")
+
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ok).Should(BeFalse())
+		})
+	})
+
+	It("fails without a split manager", func() {
+		subject, err := NewProvider(mockSplitClient)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		_, err = subject.FlagNames()
+		Ω(err).Should(MatchError(ErrNoSplitManager))
+		_, err = subject.Flags()
+		Ω(err).Should(MatchError(ErrNoSplitManager))
+		_, _, err = subject.Flag("checkout")
+		Ω(err).Should(MatchError(ErrNoSplitManager))
+	})
+})
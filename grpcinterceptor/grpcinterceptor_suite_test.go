@@ -0,0 +1,13 @@
+package grpcinterceptor_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGRPCInterceptor(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "gRPC Interceptor Suite")
+}
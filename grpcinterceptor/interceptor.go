@@ -0,0 +1,114 @@
+// Package grpcinterceptor propagates the OpenFeature evaluation context across gRPC calls, so that downstream
+// services evaluate flags for the same targeting key and attributes as the caller. Client interceptors write the
+// targeting key and a chosen subset of the attributes of the caller's transaction context to the outgoing
+// metadata, and server interceptors restore them as the transaction context of the call.
+//
+// Attributes travel as JSON, so numbers arrive as float64 and other values as their JSON form. Servers trust
+// the context callers send, so only use the server interceptors for calls from trusted services.
+package grpcinterceptor
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey is the metadata key holding the propagated evaluation context.
+const MetadataKey = "openfeature-context-bin"
+
+// Config selects the part of the evaluation context that is propagated.
+type Config struct {
+	// Attributes lists the attributes propagated along with the targeting key. Other attributes are neither sent
+	// by client interceptors nor restored by server interceptors.
+	Attributes []string
+}
+
+type propagatedContext struct {
+	TargetingKey string         `json:"targetingKey,omitempty"`
+	Attributes   map[string]any `json:"attributes,omitempty"`
+}
+
+// UnaryClientInterceptor propagates the transaction context of unary calls.
+func UnaryClientInterceptor(config Config) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(Inject(ctx, config), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor propagates the transaction context of streaming calls.
+func StreamClientInterceptor(config Config) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(Inject(ctx, config), desc, cc, method, opts...)
+	}
+}
+
+// UnaryServerInterceptor restores the propagated evaluation context of unary calls as their transaction context.
+func UnaryServerInterceptor(config Config) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(Extract(ctx, config), req)
+	}
+}
+
+// StreamServerInterceptor restores the propagated evaluation context of streaming calls as their transaction
+// context.
+func StreamServerInterceptor(config Config) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, contextStream{ServerStream: stream, ctx: Extract(stream.Context(), config)})
+	}
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream contextStream) Context() context.Context {
+	return stream.ctx
+}
+
+// Inject adds the configured part of the transaction context of ctx to its outgoing metadata. Contexts without a
+// targeting key or propagated attributes are returned unchanged.
+func Inject(ctx context.Context, config Config) context.Context {
+	evalCtx := openfeature.TransactionContext(ctx)
+	propagated := propagatedContext{
+		TargetingKey: evalCtx.TargetingKey(),
+		Attributes:   selectAttributes(evalCtx.Attributes(), config.Attributes),
+	}
+	if propagated.TargetingKey == "" && len(propagated.Attributes) == 0 {
+		return ctx
+	}
+	encoded, err := json.Marshal(propagated)
+	if err != nil {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, string(encoded))
+}
+
+// Extract merges the evaluation context propagated in the incoming metadata of ctx into its transaction context.
+// Propagated values take precedence over those already in the transaction context. Metadata that cannot be
+// decoded is ignored.
+func Extract(ctx context.Context, config Config) context.Context {
+	values := metadata.ValueFromIncomingContext(ctx, MetadataKey)
+	if len(values) == 0 {
+		return ctx
+	}
+	var propagated propagatedContext
+	if err := json.Unmarshal([]byte(values[0]), &propagated); err != nil {
+		return ctx
+	}
+	evalCtx := openfeature.NewEvaluationContext(propagated.TargetingKey, selectAttributes(propagated.Attributes, config.Attributes))
+	return openfeature.MergeTransactionContext(ctx, evalCtx)
+}
+
+func selectAttributes(attributes map[string]any, names []string) map[string]any {
+	selected := make(map[string]any, len(names))
+	for _, name := range names {
+		if value, ok := attributes[name]; ok {
+			selected[name] = value
+		}
+	}
+	return selected
+}
@@ -0,0 +1,119 @@
+package grpcinterceptor_test
+
+import (
+	"context"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/snap-one/fork-split-openfeature-provider-go/grpcinterceptor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// healthServer records the transaction context of the calls it handles.
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	received chan openfeature.EvaluationContext
+}
+
+func (server healthServer) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	server.received <- openfeature.TransactionContext(ctx)
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (server healthServer) Watch(_ *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	server.received <- openfeature.TransactionContext(stream.Context())
+	return stream.Send(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING})
+}
+
+var _ = Describe("Interceptors", func() {
+	var (
+		config   grpcinterceptor.Config
+		received chan openfeature.EvaluationContext
+		client   grpc_health_v1.HealthClient
+		ctx      context.Context
+	)
+
+	BeforeEach(func() {
+		config = grpcinterceptor.Config{Attributes: []string{"plan", "seats"}}
+		received = make(chan openfeature.EvaluationContext, 1)
+		listener := bufconn.Listen(1 << 20)
+		server := grpc.NewServer(
+			grpc.UnaryInterceptor(grpcinterceptor.UnaryServerInterceptor(config)),
+			grpc.StreamInterceptor(grpcinterceptor.StreamServerInterceptor(config)),
+		)
+		grpc_health_v1.RegisterHealthServer(server, healthServer{received: received})
+		go func() {
+			defer GinkgoRecover()
+			Ω(server.Serve(listener)).Should(Succeed())
+		}()
+		DeferCleanup(server.Stop)
+
+		conn, err := grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(grpcinterceptor.UnaryClientInterceptor(config)),
+			grpc.WithStreamInterceptor(grpcinterceptor.StreamClientInterceptor(config)),
+		)
+		Ω(err).ShouldNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+		client = grpc_health_v1.NewHealthClient(conn)
+		ctx = openfeature.WithTransactionContext(context.Background(), openfeature.NewEvaluationContext("user-1", map[string]any{
+			"plan":  "pro",
+			"seats": 10,
+			"email": "jane@example.com",
+		}))
+	})
+
+	It("propagates the targeting key and the chosen attributes of unary calls", func() {
+		_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var evalCtx openfeature.EvaluationContext
+		Eventually(received).Should(Receive(&evalCtx))
+		Ω(evalCtx.TargetingKey()).Should(Equal("user-1"))
+		Ω(evalCtx.Attributes()).Should(Equal(map[string]any{"plan": "pro", "seats": float64(10)}))
+	})
+
+	It("propagates the context of streaming calls", func() {
+		stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+		Ω(err).ShouldNot(HaveOccurred())
+		_, err = stream.Recv()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var evalCtx openfeature.EvaluationContext
+		Eventually(received).Should(Receive(&evalCtx))
+		Ω(evalCtx.TargetingKey()).Should(Equal("user-1"))
+		Ω(evalCtx.Attributes()).Should(Equal(map[string]any{"plan": "pro", "seats": float64(10)}))
+	})
+
+	It("only restores the chosen attributes", func() {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), grpcinterceptor.MetadataKey,
+			`{"targetingKey":"user-2","attributes":{"plan":"free","admin":true}}`)
+
+		_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var evalCtx openfeature.EvaluationContext
+		Eventually(received).Should(Receive(&evalCtx))
+		Ω(evalCtx.TargetingKey()).Should(Equal("user-2"))
+		Ω(evalCtx.Attributes()).Should(Equal(map[string]any{"plan": "free"}))
+	})
+
+	It("leaves calls without a transaction context untouched", func() {
+		_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var evalCtx openfeature.EvaluationContext
+		Eventually(received).Should(Receive(&evalCtx))
+		Ω(evalCtx.TargetingKey()).Should(BeEmpty())
+		Ω(evalCtx.Attributes()).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,80 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// Status describes whether the provider can currently serve live Split treatments.
+type Status struct {
+	// Ready is true when the Split SDK is ready and the circuit breaker, if any, is not open.
+	Ready bool `json:"ready"`
+	// State is the OpenFeature provider state: READY, STALE when serving from a snapshot or with an open circuit,
+	// or NOT_READY.
+	State openfeature.State `json:"state"`
+	// Snapshot is true while treatments are served from a snapshot restored with WithSnapshot, because the Split
+	// SDK is not ready yet.
+	Snapshot bool `json:"snapshot,omitempty"`
+	// LastSplitChange is when the most recently changed split last changed, taken from the split change numbers.
+	// It is not when the SDK last synchronized, which Split does not report. It requires a Split manager and is
+	// omitted when no splits are known.
+	LastSplitChange *time.Time `json:"lastSplitChange,omitempty"`
+	// Splits is the number of splits known to the Split manager, or nil without one.
+	Splits *int `json:"splits,omitempty"`
+	// Circuit is the state of the circuit breaker around the Split client.
+	Circuit CircuitState `json:"circuit"`
+}
+
+// Status reports the readiness of the provider, using the factory and manager given by WithSplitFactory
+// and WithSplitManager when available.
+func (provider *SplitProvider) Status() Status {
+	status := Status{
+		Circuit: provider.CircuitState(),
+	}
+	sdkReady := provider.isReady()
+	status.Ready = sdkReady && status.Circuit != CircuitOpen
+	status.Snapshot = !sdkReady && provider.snapshot != nil && provider.snapshot.restored()
+	switch {
+	case status.Ready:
+		status.State = openfeature.ReadyState
+	case status.Circuit == CircuitOpen || status.Snapshot:
+		status.State = openfeature.StaleState
+	default:
+		status.State = openfeature.NotReadyState
+	}
+	if provider.manager != nil && sdkReady {
+		views := provider.manager.Splits()
+		count := len(views)
+		status.Splits = &count
+		var changeNumber int64
+		for _, view := range views {
+			if view.ChangeNumber > changeNumber {
+				changeNumber = view.ChangeNumber
+			}
+		}
+		if changeNumber > 0 {
+			lastChange := time.UnixMilli(changeNumber).UTC()
+			status.LastSplitChange = &lastChange
+		}
+	}
+	return status
+}
+
+// HealthHandler returns an http.Handler, suitable for readiness probes, that writes the provider Status as JSON
+// with status 200 when it is ready or serving a restored snapshot, and 503 otherwise.
+func (provider *SplitProvider) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := provider.Status()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if status.Ready || status.Snapshot {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(status)
+	})
+}
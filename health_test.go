@@ -0,0 +1,157 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"github.com/splitio/go-client/splitio/client"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Health", func() {
+	var (
+		mockSplitClient  *mocks.MockSplitClient
+		mockSplitFactory *mocks.MockSplitFactory
+		mockSplitManager *mocks.MockSplitManager
+		subject          *SplitProvider
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		mockSplitFactory = mocks.NewMockSplitFactory(mockCtrl)
+		mockSplitManager = mocks.NewMockSplitManager(mockCtrl)
+		var err error
+		subject, err = NewProvider(mockSplitClient, WithSplitFactory(mockSplitFactory), WithSplitManager(mockSplitManager))
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Status", func() {
+		It("reports a ready provider with its splits", func() {
+			mockSplitFactory.EXPECT().IsReady().Return(true)
+			mockSplitManager.EXPECT().Splits().Return([]client.SplitView{
+				{Name: "checkout", ChangeNumber: 1700000000000},
+				{Name: "banner", ChangeNumber: 1700000005000},
+			})
+
+			// act
+			status := subject.Status()
+
+			splits := 2
+			lastChange := time.UnixMilli(1700000005000).UTC()
+			Ω(status).Should(Equal(Status{
+				Ready:           true,
+				State:           openfeature.ReadyState,
+				LastSplitChange: &lastChange,
+				Splits:          &splits,
+				Circuit:         CircuitClosed,
+			}))
+		})
+
+		It("reports a provider whose SDK is not ready", func() {
+			mockSplitFactory.EXPECT().IsReady().Return(false)
+
+			// act
+			status := subject.Status()
+
+			Ω(status).Should(Equal(Status{
+				Ready:   false,
+				State:   openfeature.NotReadyState,
+				Circuit: CircuitClosed,
+			}))
+		})
+
+		It("reports a provider without a factory or manager as ready", func() {
+			subject, err := NewProvider(mockSplitClient)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// act
+			status := subject.Status()
+
+			Ω(status).Should(Equal(Status{
+				Ready:   true,
+				State:   openfeature.ReadyState,
+				Circuit: CircuitClosed,
+			}))
+		})
+
+		It("reports an open circuit as stale", func() {
+			subject, err := NewProvider(mockSplitClient, WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour}))
+			Ω(err).ShouldNot(HaveOccurred())
+			mockSplitClient.EXPECT().Treatment("key", "checkout", nil).Return("control")
+			subject.StringEvaluation(context.Background(), "checkout", "", openfeature.FlattenedContext{openfeature.TargetingKey: "key"})
+
+			// act
+			status := subject.Status()
+
+			Ω(status.Ready).Should(BeFalse())
+			Ω(status.State).Should(Equal(openfeature.StaleState))
+			Ω(status.Circuit).Should(Equal(CircuitOpen))
+		})
+	})
+
+	Describe("HealthHandler", func() {
+		It("responds 200 with the status when ready", func() {
+			mockSplitFactory.EXPECT().IsReady().Return(true)
+			mockSplitManager.EXPECT().Splits().Return([]client.SplitView{{Name: "checkout"}})
+			recorder := httptest.NewRecorder()
+
+			// act
+			subject.HealthHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			Ω(recorder.Code).Should(Equal(http.StatusOK))
+			Ω(recorder.Header().Get("Content-Type")).Should(Equal("application/json"))
+			var body map[string]any
+			Ω(json.Unmarshal(recorder.Body.Bytes(), &body)).Should(Succeed())
+			Ω(body).Should(Equal(map[string]any{
+				"ready":   true,
+				"state":   "READY",
+				"splits":  1.0,
+				"circuit": "closed",
+			}))
+		})
+
+		It("responds 503 when not ready", func() {
+			mockSplitFactory.EXPECT().IsReady().Return(false)
+			recorder := httptest.NewRecorder()
+
+			// act
+			subject.HealthHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			Ω(recorder.Code).Should(Equal(http.StatusServiceUnavailable))
+			Ω(recorder.Body.String()).Should(MatchJSON(`{"ready":false,"state":"NOT_READY","circuit":"closed"}`))
+		})
+
+		It("responds 200 with state STALE while serving a restored snapshot", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "snapshot.json")
+			data, err := json.Marshal(Snapshot{SavedAt: time.Now(), Splits: []SnapshotSplit{{
+				SplitView: client.SplitView{Name: "checkout", Treatments: []string{"on"}},
+			}}})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(os.WriteFile(path, data, 0o600)).Should(Succeed())
+			subject, err := NewProvider(mockSplitClient,
+				WithSplitFactory(mockSplitFactory),
+				WithSplitManager(mockSplitManager),
+				WithSnapshot(path, 0))
+			Ω(err).ShouldNot(HaveOccurred())
+			mockSplitFactory.EXPECT().IsReady().Return(false)
+			recorder := httptest.NewRecorder()
+
+			// act
+			subject.HealthHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			Ω(recorder.Code).Should(Equal(http.StatusOK))
+			Ω(recorder.Body.String()).Should(MatchJSON(`{"ready":false,"state":"STALE","snapshot":true,"circuit":"closed"}`))
+		})
+	})
+})
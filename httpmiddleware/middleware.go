@@ -0,0 +1,182 @@
+// Package httpmiddleware builds the OpenFeature evaluation context of an HTTP request from its headers, cookies
+// and JWT claims, and stores it as OpenFeature transaction context, so that every flag evaluated while handling
+// the request uses it without the handler building it again.
+package httpmiddleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// Source extracts a value from a request, reporting whether the request has one.
+type Source func(r *http.Request) (any, bool)
+
+// Header reads the value of a request header.
+func Header(name string) Source {
+	return func(r *http.Request) (any, bool) {
+		value := r.Header.Get(name)
+		return value, value != ""
+	}
+}
+
+// Cookie reads the value of a request cookie.
+func Cookie(name string) Source {
+	return func(r *http.Request) (any, bool) {
+		cookie, err := r.Cookie(name)
+		if err != nil || cookie.Value == "" {
+			return nil, false
+		}
+		return cookie.Value, true
+	}
+}
+
+// Claim reads a claim of the request's JWT, as returned by Config.Claims.
+func Claim(name string) Source {
+	return func(r *http.Request) (any, bool) {
+		claims, _ := r.Context().Value(claimsKey{}).(map[string]any)
+		value, ok := claims[name]
+		return value, ok && value != nil
+	}
+}
+
+// SignedOverrides reads treatment overrides from a request header holding comma-separated flag=treatment pairs
+// followed by their expiry and signature, separated by semicolons, as written by SignOverrides. Headers without
+// a valid signature or past their expiry are ignored, so only holders of the secret can force treatments, and
+// only for a limited time. Map it to the attribute the provider reads overrides from, see WithContextOverrides
+// in the provider package.
+func SignedOverrides(name string, secret []byte) Source {
+	return func(r *http.Request) (any, bool) {
+		payload, signature, ok := cutLast(r.Header.Get(name), ";")
+		if !ok || len(secret) == 0 {
+			return nil, false
+		}
+		expected, err := hex.DecodeString(strings.TrimSpace(signature))
+		if err != nil || !hmac.Equal(expected, overridesSignature(payload, secret)) {
+			return nil, false
+		}
+		overrides, expiry, _ := cutLast(payload, ";")
+		expires, err := strconv.ParseInt(expiry, 10, 64)
+		if err != nil || !time.Now().Before(time.Unix(expires, 0)) {
+			return nil, false
+		}
+		return overrides, overrides != ""
+	}
+}
+
+// SignOverrides returns the header value forcing the overrides, given as comma-separated flag=treatment pairs,
+// until expires, for SignedOverrides with the same secret.
+func SignOverrides(overrides string, expires time.Time, secret []byte) string {
+	payload := overrides + ";" + strconv.FormatInt(expires.Unix(), 10)
+	return payload + ";" + hex.EncodeToString(overridesSignature(payload, secret))
+}
+
+func overridesSignature(payload string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func cutLast(s string, sep string) (before string, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// Config selects where the evaluation context of a request comes from.
+type Config struct {
+	// TargetingKey lists the sources of the targeting key, in order of preference. String values are used as
+	// they are; other values are converted to JSON.
+	TargetingKey []Source
+	// Attributes maps evaluation context attributes to their sources.
+	Attributes map[string]Source
+	// Claims returns the JWT claims of a request for Claim sources. It must only return claims of tokens that
+	// have been verified, for example by an authentication middleware earlier in the chain. Requests for which
+	// it fails are handled without claims.
+	Claims func(r *http.Request) (map[string]any, error)
+}
+
+type claimsKey struct{}
+
+// Middleware returns middleware merging the evaluation context built from each request into the request's
+// OpenFeature transaction context. Values found in the request take precedence over those already in the
+// transaction context.
+func Middleware(config Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			evalCtx := EvaluationContext(r, config)
+			next.ServeHTTP(w, r.WithContext(openfeature.MergeTransactionContext(r.Context(), evalCtx)))
+		})
+	}
+}
+
+// EvaluationContext builds the evaluation context of a request as configured.
+func EvaluationContext(r *http.Request, config Config) openfeature.EvaluationContext {
+	if config.Claims != nil {
+		if claims, err := config.Claims(r); err == nil {
+			r = r.WithContext(context.WithValue(r.Context(), claimsKey{}, claims))
+		}
+	}
+	var targetingKey string
+	for _, source := range config.TargetingKey {
+		if value, ok := source(r); ok {
+			targetingKey = keyString(value)
+			break
+		}
+	}
+	attributes := make(map[string]any, len(config.Attributes))
+	for name, source := range config.Attributes {
+		if value, ok := source(r); ok {
+			attributes[name] = value
+		}
+	}
+	return openfeature.NewEvaluationContext(targetingKey, attributes)
+}
+
+func keyString(value any) string {
+	if key, ok := value.(string); ok {
+		return key
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// ErrNoBearerToken is returned by UnverifiedBearerClaims for requests without a bearer token.
+var ErrNoBearerToken = errors.New("no bearer token")
+
+// UnverifiedBearerClaims decodes the claims of the JWT in the request's Authorization bearer header without
+// verifying its signature. Only use it for Config.Claims behind a gateway or middleware that rejects requests
+// with invalid tokens.
+func UnverifiedBearerClaims(r *http.Request) (map[string]any, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return nil, ErrNoBearerToken
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, err
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
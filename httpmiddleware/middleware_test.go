@@ -0,0 +1,165 @@
+package httpmiddleware_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	splitProvider "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/httpmiddleware"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func bearer(payload string) string {
+	return "Bearer header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+var _ = Describe("Middleware", func() {
+	var (
+		config  httpmiddleware.Config
+		request *http.Request
+	)
+
+	BeforeEach(func() {
+		config = httpmiddleware.Config{
+			TargetingKey: []httpmiddleware.Source{
+				httpmiddleware.Claim("sub"),
+				httpmiddleware.Header("X-User-Id"),
+				httpmiddleware.Cookie("visitor"),
+			},
+			Attributes: map[string]httpmiddleware.Source{
+				"plan":    httpmiddleware.Claim("plan"),
+				"country": httpmiddleware.Header("X-Country"),
+				"theme":   httpmiddleware.Cookie("theme"),
+			},
+			Claims: httpmiddleware.UnverifiedBearerClaims,
+		}
+		request = httptest.NewRequest(http.MethodGet, "/", nil)
+	})
+
+	serve := func() openfeature.EvaluationContext {
+		var evalCtx openfeature.EvaluationContext
+		handler := httpmiddleware.Middleware(config)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			evalCtx = openfeature.TransactionContext(r.Context())
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+		return evalCtx
+	}
+
+	It("reads headers and cookies", func() {
+		request.Header.Set("X-User-Id", "user-1")
+		request.Header.Set("X-Country", "FR")
+		request.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+
+		evalCtx := serve()
+
+		Ω(evalCtx.TargetingKey()).Should(Equal("user-1"))
+		Ω(evalCtx.Attributes()).Should(Equal(map[string]any{"country": "FR", "theme": "dark"}))
+	})
+
+	It("reads JWT claims and prefers the first targeting key source found", func() {
+		request.Header.Set("Authorization", bearer(`{"sub":"user-2","plan":"pro"}`))
+		request.Header.Set("X-User-Id", "user-1")
+
+		evalCtx := serve()
+
+		Ω(evalCtx.TargetingKey()).Should(Equal("user-2"))
+		Ω(evalCtx.Attributes()).Should(Equal(map[string]any{"plan": "pro"}))
+	})
+
+	It("converts targeting keys that are not strings to JSON", func() {
+		request.Header.Set("Authorization", bearer(`{"sub":42}`))
+
+		Ω(serve().TargetingKey()).Should(Equal("42"))
+	})
+
+	It("falls back to cookies and ignores invalid tokens", func() {
+		request.Header.Set("Authorization", "Bearer not-a-jwt")
+		request.AddCookie(&http.Cookie{Name: "visitor", Value: "visitor-1"})
+
+		Ω(serve().TargetingKey()).Should(Equal("visitor-1"))
+	})
+
+	It("merges with the values already in the transaction context", func() {
+		request.Header.Set("X-User-Id", "user-1")
+		request.Header.Set("X-Country", "FR")
+		request = request.WithContext(openfeature.WithTransactionContext(request.Context(),
+			openfeature.NewEvaluationContext("user-0", map[string]any{"country": "DE", "tenant": "acme"})))
+
+		evalCtx := serve()
+
+		Ω(evalCtx.TargetingKey()).Should(Equal("user-1"))
+		Ω(evalCtx.Attributes()).Should(Equal(map[string]any{"country": "FR", "tenant": "acme"}))
+	})
+
+	It("reads overrides signed with the secret", func() {
+		secret := []byte("secret")
+		expires := time.Now().Add(time.Hour)
+		config.Attributes[splitProvider.OverridesAttribute] = httpmiddleware.SignedOverrides("X-Split-Overrides", secret)
+		request.Header.Set("X-Split-Overrides", httpmiddleware.SignOverrides("checkout=on", expires, secret))
+
+		Ω(serve().Attributes()).Should(HaveKeyWithValue(splitProvider.OverridesAttribute, "checkout=on"))
+
+		request.Header.Set("X-Split-Overrides", httpmiddleware.SignOverrides("checkout=on", expires, []byte("guess")))
+
+		Ω(serve().Attributes()).ShouldNot(HaveKey(splitProvider.OverridesAttribute))
+
+		request.Header.Set("X-Split-Overrides", "checkout=on")
+
+		Ω(serve().Attributes()).ShouldNot(HaveKey(splitProvider.OverridesAttribute))
+	})
+
+	It("ignores expired overrides", func() {
+		secret := []byte("secret")
+		config.Attributes[splitProvider.OverridesAttribute] = httpmiddleware.SignedOverrides("X-Split-Overrides", secret)
+		request.Header.Set("X-Split-Overrides", httpmiddleware.SignOverrides("checkout=on", time.Now().Add(-time.Second), secret))
+
+		Ω(serve().Attributes()).ShouldNot(HaveKey(splitProvider.OverridesAttribute))
+	})
+
+	It("ignores overrides whose expiry was changed", func() {
+		secret := []byte("secret")
+		config.Attributes[splitProvider.OverridesAttribute] = httpmiddleware.SignedOverrides("X-Split-Overrides", secret)
+		expired := time.Now().Add(-time.Second)
+		signed := httpmiddleware.SignOverrides("checkout=on", expired, secret)
+		extended := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+		request.Header.Set("X-Split-Overrides",
+			strings.Replace(signed, strconv.FormatInt(expired.Unix(), 10), extended, 1))
+
+		Ω(serve().Attributes()).ShouldNot(HaveKey(splitProvider.OverridesAttribute))
+	})
+
+	It("reports requests without a bearer token", func() {
+		_, err := httpmiddleware.UnverifiedBearerClaims(request)
+
+		Ω(err).Should(MatchError(httpmiddleware.ErrNoBearerToken))
+	})
+
+	It("provides the context to flags evaluated by handlers", func() {
+		mockSplitClient := mocks.NewMockSplitClient(gomock.NewController(GinkgoT()))
+		mockSplitClient.EXPECT().Treatment("user-1", "checkout", map[string]any{"country": "FR"}).Return("on")
+		provider, err := splitProvider.NewProvider(mockSplitClient)
+		Ω(err).ShouldNot(HaveOccurred())
+		domain := uuid.NewString()
+		Ω(openfeature.SetNamedProviderAndWait(domain, provider)).Should(Succeed())
+		client := openfeature.NewClient(domain)
+		request.Header.Set("X-User-Id", "user-1")
+		request.Header.Set("X-Country", "FR")
+
+		var enabled bool
+		handler := httpmiddleware.Middleware(config)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			enabled = client.Boolean(r.Context(), "checkout", false, openfeature.EvaluationContext{})
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		Ω(enabled).Should(BeTrue())
+	})
+})
@@ -0,0 +1,228 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// MetadataSyntheticKey is the FlagMetadata key set to true when a flag was evaluated with an anonymous key
+// because the context had no targeting key.
+const MetadataSyntheticKey = "syntheticKey"
+
+var errTargetingKeyMissing = openfeature.NewTargetingKeyMissingResolutionError("Targeting key is required and missing.")
+
+// TrafficTypes selects which context attribute holds the Split key of an evaluation, so that one provider can
+// evaluate flags of several Split traffic types, such as user, account and device.
+type TrafficTypes struct {
+	// Keys maps traffic types to the context attribute holding their keys, for example "account" to "accountId".
+	// Evaluations of any other traffic type use the targeting key.
+	Keys map[string]string
+	// Flags maps flags to their traffic type.
+	Flags map[string]string
+	// Attribute, when set, names a context attribute whose value selects the traffic type of an evaluation.
+	// It takes precedence over Flags.
+	Attribute string
+}
+
+// WithTrafficTypes evaluates flags with the key of their traffic type instead of the targeting key.
+func WithTrafficTypes(trafficTypes TrafficTypes) Option {
+	return func(provider *SplitProvider) error {
+		if len(trafficTypes.Keys) == 0 {
+			return errors.New("traffic types need at least one key attribute")
+		}
+		for trafficType, attribute := range trafficTypes.Keys {
+			if attribute == "" {
+				return fmt.Errorf("traffic type %q has no key attribute", trafficType)
+			}
+		}
+		provider.trafficTypes = &trafficTypes
+		return nil
+	}
+}
+
+// keyAttribute returns the context attribute holding the Split key of the flag.
+func (trafficTypes *TrafficTypes) keyAttribute(flag string, evalCtx openfeature.FlattenedContext) (string, *openfeature.ResolutionError) {
+	if trafficTypes == nil {
+		return openfeature.TargetingKey, nil
+	}
+	trafficType, ok := trafficTypes.Flags[flag]
+	if trafficTypes.Attribute != "" {
+		if value, present := evalCtx[trafficTypes.Attribute]; present {
+			if trafficType, ok = value.(string); !ok {
+				err := openfeature.NewInvalidContextResolutionError(
+					fmt.Sprintf("Context attribute %q must name a traffic type, got %T.", trafficTypes.Attribute, value))
+				return "", &err
+			}
+		}
+	}
+	if attribute, known := trafficTypes.Keys[trafficType]; ok && known {
+		return attribute, nil
+	}
+	return openfeature.TargetingKey, nil
+}
+
+// key returns the context attribute holding the keys of the traffic type, if one is configured.
+func (trafficTypes *TrafficTypes) key(trafficType string) (string, bool) {
+	if trafficTypes == nil {
+		return "", false
+	}
+	attribute, ok := trafficTypes.Keys[trafficType]
+	return attribute, ok
+}
+
+// attribute returns the context attribute naming the traffic type of tracked events.
+func (trafficTypes *TrafficTypes) attribute() string {
+	if trafficTypes == nil || trafficTypes.Attribute == "" {
+		return TrafficTypeKey
+	}
+	return trafficTypes.Attribute
+}
+
+// WithTargetingKeyFallback evaluates contexts without a targeting key with the first of the given attributes
+// they hold, for example "userId", then "sessionId", then "deviceId".
+func WithTargetingKeyFallback(attributes ...string) Option {
+	return func(provider *SplitProvider) error {
+		for _, attribute := range attributes {
+			if attribute == "" {
+				return errors.New("targeting key fallback attributes must not be empty")
+			}
+		}
+		provider.keyFallback = attributes
+		return nil
+	}
+}
+
+// AnonymousConfig configures the synthetic key of evaluations without a targeting key.
+type AnonymousConfig struct {
+	// HashAttributes, when set, derives the key from a hash of the values of these context attributes, so that
+	// contexts with the same values get the same treatment.
+	HashAttributes []string
+	// Key is the constant key used when no HashAttributes are set or the context holds none of them.
+	Key string
+}
+
+// WithAnonymousEvaluation evaluates flags without a targeting key, such as global kill switches, with a synthetic
+// key instead of failing with TARGETING_KEY_MISSING. The FlagMetadata of such evaluations has MetadataSyntheticKey
+// set to true. The targeting key fallback attributes, if any, are tried first.
+func WithAnonymousEvaluation(config AnonymousConfig) Option {
+	return func(provider *SplitProvider) error {
+		if len(config.HashAttributes) == 0 && config.Key == "" {
+			return errors.New("anonymous evaluation needs a key or attributes to hash")
+		}
+		provider.anonymous = &config
+		return nil
+	}
+}
+
+// key returns the synthetic key for the context, if the configuration can build one. Hashes are salted with the
+// HashSalt of the attribute filter, if any, so that they cannot be matched against hashes of known values.
+func (anonymous *AnonymousConfig) key(evalCtx openfeature.FlattenedContext, filter *attributeFilter) (string, bool) {
+	if anonymous == nil {
+		return "", false
+	}
+	hash := sha256.New()
+	if filter != nil {
+		hash.Write([]byte(filter.salt))
+	}
+	hashed := false
+	for _, attribute := range anonymous.HashAttributes {
+		value, ok := evalCtx[attribute]
+		if !ok {
+			continue
+		}
+		encoded, err := json.Marshal([]any{attribute, value})
+		if err != nil {
+			encoded = []byte(fmt.Sprintf("%q:%v", attribute, value))
+		}
+		hash.Write(encoded)
+		hashed = true
+	}
+	if hashed {
+		return hex.EncodeToString(hash.Sum(nil)), true
+	}
+	return anonymous.Key, anonymous.Key != ""
+}
+
+// splitKey returns the key to evaluate the flag with, whether it is a synthetic anonymous key, or the resolution
+// error explaining why there is none. A missing, nil or blank key counts as absent; any other value that is not
+// a string is invalid.
+func (provider *SplitProvider) splitKey(flag string, evalCtx openfeature.FlattenedContext) (any, bool, *openfeature.ResolutionError) {
+	attribute, resolutionErr := provider.trafficTypes.keyAttribute(flag, evalCtx)
+	if resolutionErr != nil {
+		return nil, false, resolutionErr
+	}
+	key, ok, resolutionErr := contextKey(evalCtx, attribute)
+	if ok || resolutionErr != nil {
+		return provider.attributeFilter.key(attribute, key), false, resolutionErr
+	}
+	if attribute != openfeature.TargetingKey {
+		err := openfeature.NewTargetingKeyMissingResolutionError(
+			fmt.Sprintf("Context attribute %q holding the Split key is required and missing.", attribute))
+		return nil, false, &err
+	}
+	for _, fallback := range provider.keyFallback {
+		key, ok, resolutionErr = contextKey(evalCtx, fallback)
+		if ok || resolutionErr != nil {
+			return provider.attributeFilter.key(fallback, key), false, resolutionErr
+		}
+	}
+	if anonymousKey, ok := provider.anonymous.key(evalCtx, provider.attributeFilter); ok {
+		return anonymousKey, true, nil
+	}
+	return nil, false, &errTargetingKeyMissing
+}
+
+// validateKeyAttributes rejects key attributes denied by the attribute filter, which would otherwise reach Split as
+// keys.
+func (provider *SplitProvider) validateKeyAttributes() error {
+	attributes := append([]string{}, provider.keyFallback...)
+	if provider.trafficTypes != nil {
+		for _, attribute := range provider.trafficTypes.Keys {
+			attributes = append(attributes, attribute)
+		}
+	}
+	if provider.anonymous != nil {
+		attributes = append(attributes, provider.anonymous.HashAttributes...)
+	}
+	for _, attribute := range attributes {
+		if provider.attributeFilter.denies(attribute) {
+			return fmt.Errorf("key attribute %q is denied by the attribute filter", attribute)
+		}
+	}
+	return nil
+}
+
+// withSyntheticKey returns a copy of the metadata marking the evaluation as made with a synthetic key.
+func withSyntheticKey(metadata openfeature.FlagMetadata) openfeature.FlagMetadata {
+	marked := make(openfeature.FlagMetadata, len(metadata)+1)
+	for key, value := range metadata {
+		marked[key] = value
+	}
+	marked[MetadataSyntheticKey] = true
+	return marked
+}
+
+// contextKey returns the Split key held by the context attribute, if it holds one. The key is returned as
+// found in the context, a string, to avoid converting it again for the Split client.
+func contextKey(evalCtx openfeature.FlattenedContext, attribute string) (any, bool, *openfeature.ResolutionError) {
+	value := evalCtx[attribute]
+	if value == nil {
+		return nil, false, nil
+	}
+	key, ok := value.(string)
+	if !ok {
+		err := openfeature.NewInvalidContextResolutionError(
+			fmt.Sprintf("Context attribute %q must be a string to be used as the Split key, got %T.", attribute, value))
+		return nil, false, &err
+	}
+	if strings.TrimSpace(key) == "" {
+		return nil, false, nil
+	}
+	return value, true, nil
+}
@@ -0,0 +1,263 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Split keys", func() {
+	var mockSplitClient *mocks.MockSplitClient
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+	})
+
+	Describe("traffic types", func() {
+		var subject *SplitProvider
+
+		BeforeEach(func() {
+			var err error
+			subject, err = NewProvider(mockSplitClient, WithTrafficTypes(TrafficTypes{
+				Keys:      map[string]string{"account": "accountId", "device": "deviceId"},
+				Flags:     map[string]string{"billing-v2": "account"},
+				Attribute: "trafficType",
+			}))
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("rejects traffic types without key attributes", func() {
+			_, err := NewProvider(mockSplitClient, WithTrafficTypes(TrafficTypes{}))
+			Ω(err).Should(HaveOccurred())
+			_, err = NewProvider(mockSplitClient, WithTrafficTypes(TrafficTypes{Keys: map[string]string{"account": ""}}))
+			Ω(err).Should(MatchError(`traffic type "account" has no key attribute`))
+		})
+
+		It("uses the key of the traffic type configured for the flag", func() {
+			mockSplitClient.EXPECT().
+				Treatment("account-1", "billing-v2", map[string]any{"accountId": "account-1"}).
+				Return("on")
+
+			detail := subject.BooleanEvaluation(context.Background(), "billing-v2", false, openfeature.FlattenedContext{
+				openfeature.TargetingKey: "user-1",
+				"accountId":              "account-1",
+			})
+
+			Ω(detail.Value).Should(BeTrue())
+		})
+
+		It("lets a context attribute select the traffic type", func() {
+			evalCtx := openfeature.FlattenedContext{
+				openfeature.TargetingKey: "user-1",
+				"deviceId":               "device-1",
+				"trafficType":            "device",
+			}
+			mockSplitClient.EXPECT().
+				Treatment("device-1", "billing-v2", map[string]any{"deviceId": "device-1", "trafficType": "device"}).
+				Return("off")
+
+			detail := subject.BooleanEvaluation(context.Background(), "billing-v2", true, evalCtx)
+
+			Ω(detail.Value).Should(BeFalse())
+		})
+
+		It("uses the targeting key for other traffic types", func() {
+			mockSplitClient.EXPECT().
+				Treatment("user-1", "checkout", map[string]any{"trafficType": "user"}).
+				Return("on")
+
+			detail := subject.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+				openfeature.TargetingKey: "user-1",
+				"trafficType":            "user",
+			})
+
+			Ω(detail.Value).Should(BeTrue())
+		})
+
+		It("fails when the key of the traffic type is missing", func() {
+			detail := subject.BooleanEvaluation(context.Background(), "billing-v2", true, openfeature.FlattenedContext{
+				openfeature.TargetingKey: "user-1",
+			})
+
+			Ω(detail).Should(Equal(openfeature.BoolResolutionDetail{
+				Value: true,
+				ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+					ResolutionError: openfeature.NewTargetingKeyMissingResolutionError(`Context attribute "accountId" holding the Split key is required and missing.`),
+					Reason:          openfeature.ErrorReason,
+				},
+			}))
+		})
+
+		It("rejects a traffic type attribute that is not a string", func() {
+			detail := subject.StringEvaluation(context.Background(), "checkout", "default", openfeature.FlattenedContext{
+				openfeature.TargetingKey: "user-1",
+				"trafficType":            7,
+			})
+
+			Ω(detail.Value).Should(Equal("default"))
+			Ω(detail.ResolutionError).Should(Equal(openfeature.NewInvalidContextResolutionError(`Context attribute "trafficType" must name a traffic type, got int.`)))
+		})
+	})
+
+	Describe("targeting key", func() {
+		DescribeTable("rejects unusable targeting keys",
+			func(key any, expected openfeature.ResolutionError) {
+				subject, err := NewProvider(mockSplitClient)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				detail := subject.IntEvaluation(context.Background(), "limit", 13, openfeature.FlattenedContext{
+					openfeature.TargetingKey: key,
+				})
+
+				Ω(detail).Should(Equal(openfeature.IntResolutionDetail{
+					Value: 13,
+					ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+						ResolutionError: expected,
+						Reason:          openfeature.ErrorReason,
+					},
+				}))
+			},
+			Entry("nil", nil, openfeature.NewTargetingKeyMissingResolutionError("Targeting key is required and missing.")),
+			Entry("empty", "", openfeature.NewTargetingKeyMissingResolutionError("Targeting key is required and missing.")),
+			Entry("blank", "  ", openfeature.NewTargetingKeyMissingResolutionError("Targeting key is required and missing.")),
+			Entry("not a string", 42, openfeature.NewInvalidContextResolutionError(`Context attribute "targetingKey" must be a string to be used as the Split key, got int.`)),
+		)
+
+		Describe("fallback", func() {
+			var subject *SplitProvider
+
+			BeforeEach(func() {
+				var err error
+				subject, err = NewProvider(mockSplitClient, WithTargetingKeyFallback("userId", "sessionId", "deviceId"))
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+
+			It("rejects empty attribute names", func() {
+				_, err := NewProvider(mockSplitClient, WithTargetingKeyFallback("userId", ""))
+				Ω(err).Should(HaveOccurred())
+			})
+
+			It("prefers the targeting key", func() {
+				mockSplitClient.EXPECT().Treatment("user-1", "checkout", map[string]any{"sessionId": "session-1"}).Return("on")
+
+				detail := subject.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+					openfeature.TargetingKey: "user-1",
+					"sessionId":              "session-1",
+				})
+
+				Ω(detail.Value).Should(BeTrue())
+			})
+
+			It("uses the first attribute holding a key", func() {
+				evalCtx := openfeature.FlattenedContext{
+					openfeature.TargetingKey: "",
+					"userId":                 nil,
+					"sessionId":              "session-1",
+					"deviceId":               "device-1",
+				}
+				mockSplitClient.EXPECT().
+					Treatment("session-1", "checkout", map[string]any{"userId": nil, "sessionId": "session-1", "deviceId": "device-1"}).
+					Return("on")
+
+				detail := subject.BooleanEvaluation(context.Background(), "checkout", false, evalCtx)
+
+				Ω(detail.Value).Should(BeTrue())
+			})
+
+			It("fails when no attribute holds a key", func() {
+				detail := subject.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+					"plan": "pro",
+				})
+
+				Ω(detail.ResolutionError).Should(Equal(openfeature.NewTargetingKeyMissingResolutionError("Targeting key is required and missing.")))
+			})
+
+			It("fails on a fallback attribute that is not a string", func() {
+				detail := subject.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+					"userId": 7,
+				})
+
+				Ω(detail.ResolutionError).Should(Equal(openfeature.NewInvalidContextResolutionError(`Context attribute "userId" must be a string to be used as the Split key, got int.`)))
+			})
+		})
+	})
+
+	Describe("anonymous evaluation", func() {
+		It("requires a key or attributes to hash", func() {
+			_, err := NewProvider(mockSplitClient, WithAnonymousEvaluation(AnonymousConfig{}))
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("evaluates contexts without a targeting key with the constant key", func() {
+			subject, err := NewProvider(mockSplitClient, WithAnonymousEvaluation(AnonymousConfig{Key: "anonymous"}))
+			Ω(err).ShouldNot(HaveOccurred())
+			mockSplitClient.EXPECT().Treatment("anonymous", "kill-switch", nil).Return("off")
+
+			detail := subject.BooleanEvaluation(context.Background(), "kill-switch", true, openfeature.FlattenedContext{})
+
+			Ω(detail).Should(Equal(openfeature.BoolResolutionDetail{
+				Value: false,
+				ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+					Reason:       openfeature.TargetingMatchReason,
+					Variant:      "off",
+					FlagMetadata: openfeature.FlagMetadata{MetadataSyntheticKey: true},
+				},
+			}))
+		})
+
+		It("keeps using the targeting key when there is one", func() {
+			subject, err := NewProvider(mockSplitClient, WithAnonymousEvaluation(AnonymousConfig{Key: "anonymous"}))
+			Ω(err).ShouldNot(HaveOccurred())
+			mockSplitClient.EXPECT().Treatment("user-1", "kill-switch", nil).Return("off")
+
+			detail := subject.BooleanEvaluation(context.Background(), "kill-switch", true, openfeature.FlattenedContext{
+				openfeature.TargetingKey: "user-1",
+			})
+
+			Ω(detail.FlagMetadata).ShouldNot(HaveKey(MetadataSyntheticKey))
+		})
+
+		It("hashes the selected attributes into a deterministic key", func() {
+			subject, err := NewProvider(mockSplitClient, WithAnonymousEvaluation(AnonymousConfig{
+				HashAttributes: []string{"ip", "userAgent"},
+				Key:            "anonymous",
+			}))
+			Ω(err).ShouldNot(HaveOccurred())
+			var keys []any
+			mockSplitClient.EXPECT().Treatment(gomock.Any(), "banner", gomock.Any()).
+				DoAndReturn(func(key any, _ string, _ map[string]any) string {
+					keys = append(keys, key)
+					return "on"
+				}).
+				Times(4)
+
+			evaluate := func(evalCtx openfeature.FlattenedContext) {
+				subject.BooleanEvaluation(context.Background(), "banner", false, evalCtx)
+			}
+			evaluate(openfeature.FlattenedContext{"ip": "10.0.0.1", "userAgent": "curl", "plan": "free"})
+			evaluate(openfeature.FlattenedContext{"ip": "10.0.0.1", "userAgent": "curl", "plan": "pro"})
+			evaluate(openfeature.FlattenedContext{"ip": "10.0.0.2", "userAgent": "curl"})
+			evaluate(openfeature.FlattenedContext{"plan": "pro"})
+
+			Ω(keys[0]).Should(MatchRegexp("^[0-9a-f]{64}$"))
+			Ω(keys[1]).Should(Equal(keys[0]))
+			Ω(keys[2]).ShouldNot(Equal(keys[0]))
+			Ω(keys[3]).Should(Equal("anonymous"))
+		})
+
+		It("fails without hashed attributes or a constant key", func() {
+			subject, err := NewProvider(mockSplitClient, WithAnonymousEvaluation(AnonymousConfig{HashAttributes: []string{"ip"}}))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			detail := subject.BooleanEvaluation(context.Background(), "banner", false, openfeature.FlattenedContext{})
+
+			Ω(detail.ResolutionError).Should(Equal(openfeature.NewTargetingKeyMissingResolutionError("Targeting key is required and missing.")))
+		})
+	})
+})
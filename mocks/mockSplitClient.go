@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -package mocks -source=splitClient.go -destination=mocks/mockSplitClient.go
+//	mockgen -package mocks -source=splitClient.go -destination=mocks/mockSplitClient.go -mock_names=ISplitClient=MockSplitClient,ISplitClientWithConfig=MockSplitClientWithConfig,ISplitTracker=MockSplitTracker,ISplitFactory=MockSplitFactory,ISplitManager=MockSplitManager
 //
 
 // Package mocks is a generated GoMock package.
@@ -12,10 +12,11 @@ package mocks
 import (
 	reflect "reflect"
 
+	client "github.com/splitio/go-client/splitio/client"
 	gomock "go.uber.org/mock/gomock"
 )
 
-// MockSplitClient is a mock of SplitClient interface.
+// MockSplitClient is a mock of ISplitClient interface.
 type MockSplitClient struct {
 	ctrl     *gomock.Controller
 	recorder *MockSplitClientMockRecorder
@@ -52,3 +53,197 @@ func (mr *MockSplitClientMockRecorder) Treatment(key, feature, attributes any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Treatment", reflect.TypeOf((*MockSplitClient)(nil).Treatment), key, feature, attributes)
 }
+
+// MockSplitClientWithConfig is a mock of ISplitClientWithConfig interface.
+type MockSplitClientWithConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockSplitClientWithConfigMockRecorder
+	isgomock struct{}
+}
+
+// MockSplitClientWithConfigMockRecorder is the mock recorder for MockSplitClientWithConfig.
+type MockSplitClientWithConfigMockRecorder struct {
+	mock *MockSplitClientWithConfig
+}
+
+// NewMockSplitClientWithConfig creates a new mock instance.
+func NewMockSplitClientWithConfig(ctrl *gomock.Controller) *MockSplitClientWithConfig {
+	mock := &MockSplitClientWithConfig{ctrl: ctrl}
+	mock.recorder = &MockSplitClientWithConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSplitClientWithConfig) EXPECT() *MockSplitClientWithConfigMockRecorder {
+	return m.recorder
+}
+
+// TreatmentWithConfig mocks base method.
+func (m *MockSplitClientWithConfig) TreatmentWithConfig(key any, feature string, attributes map[string]any) client.TreatmentResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TreatmentWithConfig", key, feature, attributes)
+	ret0, _ := ret[0].(client.TreatmentResult)
+	return ret0
+}
+
+// TreatmentWithConfig indicates an expected call of TreatmentWithConfig.
+func (mr *MockSplitClientWithConfigMockRecorder) TreatmentWithConfig(key, feature, attributes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TreatmentWithConfig", reflect.TypeOf((*MockSplitClientWithConfig)(nil).TreatmentWithConfig), key, feature, attributes)
+}
+
+// MockSplitTracker is a mock of ISplitTracker interface.
+type MockSplitTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockSplitTrackerMockRecorder
+	isgomock struct{}
+}
+
+// MockSplitTrackerMockRecorder is the mock recorder for MockSplitTracker.
+type MockSplitTrackerMockRecorder struct {
+	mock *MockSplitTracker
+}
+
+// NewMockSplitTracker creates a new mock instance.
+func NewMockSplitTracker(ctrl *gomock.Controller) *MockSplitTracker {
+	mock := &MockSplitTracker{ctrl: ctrl}
+	mock.recorder = &MockSplitTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSplitTracker) EXPECT() *MockSplitTrackerMockRecorder {
+	return m.recorder
+}
+
+// Track mocks base method.
+func (m *MockSplitTracker) Track(key, trafficType, eventType string, value any, properties map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Track", key, trafficType, eventType, value, properties)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Track indicates an expected call of Track.
+func (mr *MockSplitTrackerMockRecorder) Track(key, trafficType, eventType, value, properties any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Track", reflect.TypeOf((*MockSplitTracker)(nil).Track), key, trafficType, eventType, value, properties)
+}
+
+// MockSplitFactory is a mock of ISplitFactory interface.
+type MockSplitFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockSplitFactoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSplitFactoryMockRecorder is the mock recorder for MockSplitFactory.
+type MockSplitFactoryMockRecorder struct {
+	mock *MockSplitFactory
+}
+
+// NewMockSplitFactory creates a new mock instance.
+func NewMockSplitFactory(ctrl *gomock.Controller) *MockSplitFactory {
+	mock := &MockSplitFactory{ctrl: ctrl}
+	mock.recorder = &MockSplitFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSplitFactory) EXPECT() *MockSplitFactoryMockRecorder {
+	return m.recorder
+}
+
+// BlockUntilReady mocks base method.
+func (m *MockSplitFactory) BlockUntilReady(timer int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUntilReady", timer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUntilReady indicates an expected call of BlockUntilReady.
+func (mr *MockSplitFactoryMockRecorder) BlockUntilReady(timer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUntilReady", reflect.TypeOf((*MockSplitFactory)(nil).BlockUntilReady), timer)
+}
+
+// IsReady mocks base method.
+func (m *MockSplitFactory) IsReady() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReady")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsReady indicates an expected call of IsReady.
+func (mr *MockSplitFactoryMockRecorder) IsReady() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReady", reflect.TypeOf((*MockSplitFactory)(nil).IsReady))
+}
+
+// MockSplitManager is a mock of ISplitManager interface.
+type MockSplitManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockSplitManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockSplitManagerMockRecorder is the mock recorder for MockSplitManager.
+type MockSplitManagerMockRecorder struct {
+	mock *MockSplitManager
+}
+
+// NewMockSplitManager creates a new mock instance.
+func NewMockSplitManager(ctrl *gomock.Controller) *MockSplitManager {
+	mock := &MockSplitManager{ctrl: ctrl}
+	mock.recorder = &MockSplitManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSplitManager) EXPECT() *MockSplitManagerMockRecorder {
+	return m.recorder
+}
+
+// Split mocks base method.
+func (m *MockSplitManager) Split(feature string) *client.SplitView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Split", feature)
+	ret0, _ := ret[0].(*client.SplitView)
+	return ret0
+}
+
+// Split indicates an expected call of Split.
+func (mr *MockSplitManagerMockRecorder) Split(feature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Split", reflect.TypeOf((*MockSplitManager)(nil).Split), feature)
+}
+
+// SplitNames mocks base method.
+func (m *MockSplitManager) SplitNames() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SplitNames")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// SplitNames indicates an expected call of SplitNames.
+func (mr *MockSplitManagerMockRecorder) SplitNames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitNames", reflect.TypeOf((*MockSplitManager)(nil).SplitNames))
+}
+
+// Splits mocks base method.
+func (m *MockSplitManager) Splits() []client.SplitView {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Splits")
+	ret0, _ := ret[0].([]client.SplitView)
+	return ret0
+}
+
+// Splits indicates an expected call of Splits.
+func (mr *MockSplitManagerMockRecorder) Splits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Splits", reflect.TypeOf((*MockSplitManager)(nil).Splits))
+}
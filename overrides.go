@@ -0,0 +1,228 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+const (
+	// OverridesAttribute is the default context attribute holding treatment overrides, see WithContextOverrides.
+	OverridesAttribute = "splitOverrides"
+	// SourceOverride marks treatments forced by an override instead of evaluated by Split.
+	SourceOverride = "override"
+	// MetadataOverrideKey is the FlagMetadata key naming the kind of override that forced a treatment:
+	// "context" for WithContextOverrides, and "key", "attribute" or "global" for the scope of Override.
+	MetadataOverrideKey = "override"
+)
+
+// WithContextOverrides serves the treatments forced by the context attribute (OverridesAttribute when empty)
+// instead of evaluating the overridden flags, so that QA can pin a treatment for a single request without
+// changing Split targeting. The attribute holds a map of flag names to treatments, or a string such as
+// "checkout=on,theme=dark" as read from a header by httpmiddleware.SignedOverrides. Overridden flags are
+// reported with reason STATIC and FlagMetadata source "override".
+//
+// The attribute is never sent to Split. Binaries built with the nooverrides build tag ignore overrides, so
+// production builds cannot be forced into a treatment.
+func WithContextOverrides(attribute string) Option {
+	return func(provider *SplitProvider) error {
+		if attribute == "" {
+			attribute = OverridesAttribute
+		}
+		provider.overridesAttribute = attribute
+		return nil
+	}
+}
+
+// overrideEvaluation returns the treatment the context forces for the flag, if any.
+func (provider *SplitProvider) overrideEvaluation(flag string, evalContext openfeature.FlattenedContext) (evaluation, bool) {
+	if !overridesEnabled || provider.overridesAttribute == "" {
+		return evaluation{}, false
+	}
+	treatment, ok := contextOverride(evalContext[provider.overridesAttribute], flag)
+	if !ok || noTreatment(treatment) {
+		return evaluation{}, false
+	}
+	return evaluation{
+		treatment: treatment,
+		reason:    openfeature.StaticReason,
+		metadata: openfeature.FlagMetadata{
+			MetadataSourceKey:   SourceOverride,
+			MetadataOverrideKey: "context",
+		},
+	}, true
+}
+
+func contextOverride(overrides any, flag string) (string, bool) {
+	switch overrides := overrides.(type) {
+	case map[string]string:
+		treatment, ok := overrides[flag]
+		return treatment, ok
+	case map[string]any:
+		treatment, ok := overrides[flag].(string)
+		return treatment, ok
+	case string:
+		treatment, ok := ParseOverrides(overrides)[flag]
+		return treatment, ok
+	default:
+		return "", false
+	}
+}
+
+// ParseOverrides parses overrides written as comma-separated flag=treatment pairs, ignoring malformed pairs.
+func ParseOverrides(overrides string) map[string]string {
+	parsed := map[string]string{}
+	for _, pair := range strings.Split(overrides, ",") {
+		flag, treatment, ok := strings.Cut(pair, "=")
+		flag, treatment = strings.TrimSpace(flag), strings.TrimSpace(treatment)
+		if ok && flag != "" && treatment != "" {
+			parsed[flag] = treatment
+		}
+	}
+	return parsed
+}
+
+// OverrideScope restricts the evaluations an Override applies to.
+type OverrideScope func(override *programmaticOverride)
+
+// ForKey restricts an override to evaluations for the Split key.
+func ForKey(key string) OverrideScope {
+	return func(override *programmaticOverride) {
+		override.key = &key
+	}
+}
+
+// ForAttribute restricts an override to evaluations whose context attribute equals value, as compared by
+// reflect.DeepEqual, so numbers must have the type the context holds.
+func ForAttribute(name string, value any) OverrideScope {
+	return func(override *programmaticOverride) {
+		override.attributes = append(override.attributes, attributeMatch{name: name, value: value})
+	}
+}
+
+type attributeMatch struct {
+	name  string
+	value any
+}
+
+type programmaticOverride struct {
+	treatment  string
+	key        *string
+	attributes []attributeMatch
+}
+
+// scope names the scope of the override in FlagMetadata.
+func (override *programmaticOverride) scope() string {
+	switch {
+	case override.key != nil:
+		return "key"
+	case len(override.attributes) > 0:
+		return "attribute"
+	default:
+		return "global"
+	}
+}
+
+// specificity ranks overrides so that key overrides win over attribute overrides, which win over global ones.
+func (override *programmaticOverride) specificity() int {
+	specificity := len(override.attributes)
+	if override.key != nil {
+		specificity += 1 << 16
+	}
+	return specificity
+}
+
+func (override *programmaticOverride) matches(targetKey any, evalContext openfeature.FlattenedContext) bool {
+	if override.key != nil && targetKey != any(*override.key) {
+		return false
+	}
+	for _, match := range override.attributes {
+		if value, ok := evalContext[match.name]; !ok || !reflect.DeepEqual(value, match.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// overrideSet holds the overrides set with Override, by flag, in the order they were set.
+type overrideSet struct {
+	mu    sync.RWMutex
+	flags map[string][]*programmaticOverride
+}
+
+// Override serves the treatment for the flag instead of asking the Split client, for every evaluation or only
+// those matching all the scopes, until the returned function or ClearOverrides clears it. When several overrides
+// match, key overrides win over attribute overrides, which win over global ones, and the latest set wins among
+// equally specific ones. Overridden flags are reported with reason STATIC, FlagMetadata source "override" and
+// the scope of the override. Overrides are safe for concurrent use, and ignored by builds with the nooverrides
+// tag.
+func (provider *SplitProvider) Override(flag string, treatment string, scopes ...OverrideScope) (clear func()) {
+	override := &programmaticOverride{treatment: treatment}
+	for _, scope := range scopes {
+		scope(override)
+	}
+	set := &provider.overrides
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	if set.flags == nil {
+		set.flags = map[string][]*programmaticOverride{}
+	}
+	set.flags[flag] = append(set.flags[flag], override)
+	return func() {
+		set.mu.Lock()
+		defer set.mu.Unlock()
+		overrides := set.flags[flag]
+		for i, candidate := range overrides {
+			if candidate == override {
+				set.flags[flag] = append(overrides[:i:i], overrides[i+1:]...)
+				break
+			}
+		}
+		if len(set.flags[flag]) == 0 {
+			delete(set.flags, flag)
+		}
+	}
+}
+
+// ClearOverrides clears the overrides of the flags, or of every flag when none is given.
+func (provider *SplitProvider) ClearOverrides(flags ...string) {
+	set := &provider.overrides
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	if len(flags) == 0 {
+		set.flags = nil
+		return
+	}
+	for _, flag := range flags {
+		delete(set.flags, flag)
+	}
+}
+
+// programmaticEvaluation returns the treatment forced for the flag by Override, if any.
+func (provider *SplitProvider) programmaticEvaluation(flag string, targetKey any, evalContext openfeature.FlattenedContext) (evaluation, bool) {
+	if !overridesEnabled {
+		return evaluation{}, false
+	}
+	set := &provider.overrides
+	set.mu.RLock()
+	defer set.mu.RUnlock()
+	var selected *programmaticOverride
+	for _, override := range set.flags[flag] {
+		if override.matches(targetKey, evalContext) && (selected == nil || override.specificity() >= selected.specificity()) {
+			selected = override
+		}
+	}
+	if selected == nil {
+		return evaluation{}, false
+	}
+	return evaluation{
+		treatment: selected.treatment,
+		reason:    openfeature.StaticReason,
+		metadata: openfeature.FlagMetadata{
+			MetadataSourceKey:   SourceOverride,
+			MetadataOverrideKey: selected.scope(),
+		},
+	}, true
+}
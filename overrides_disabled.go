@@ -0,0 +1,6 @@
+//go:build nooverrides
+
+package fork_split_openfeature_provider_go
+
+// overridesEnabled reports whether treatment overrides are applied. The nooverrides tag ignores them.
+const overridesEnabled = false
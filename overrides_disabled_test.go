@@ -0,0 +1,54 @@
+//go:build nooverrides
+
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Overrides built with nooverrides", func() {
+	var (
+		mockSplitClient *mocks.MockSplitClient
+		subject         *SplitProvider
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		var err error
+		subject, err = NewProvider(mockSplitClient, WithContextOverrides(""))
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("ignores context overrides without sending them to Split", func() {
+		mockSplitClient.EXPECT().Treatment("user-1", "theme", map[string]any{"plan": "pro"}).Return("light")
+
+		detail := subject.StringEvaluation(context.Background(), "theme", "default", openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			"plan":                   "pro",
+			OverridesAttribute:       map[string]any{"theme": "dark"},
+		})
+
+		Ω(detail.Value).Should(Equal("light"))
+		Ω(detail.Reason).Should(Equal(openfeature.TargetingMatchReason))
+	})
+
+	It("ignores programmatic overrides", func() {
+		subject.Override("checkout", "on")
+		mockSplitClient.EXPECT().Treatment("user-1", "checkout", nil).Return("off")
+
+		detail := subject.BooleanEvaluation(context.Background(), "checkout", true, openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+		})
+
+		Ω(detail.Value).Should(BeFalse())
+		Ω(detail.Reason).Should(Equal(openfeature.TargetingMatchReason))
+	})
+})
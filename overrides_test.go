@@ -0,0 +1,181 @@
+//go:build !nooverrides
+
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Context overrides", func() {
+	var (
+		mockSplitClient *mocks.MockSplitClient
+		subject         *SplitProvider
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		var err error
+		subject, err = NewProvider(mockSplitClient, WithContextOverrides(""))
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("serves the treatments forced by the context", func() {
+		detail := subject.StringEvaluation(context.Background(), "theme", "light", openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			OverridesAttribute:       map[string]any{"theme": "dark"},
+		})
+
+		Ω(detail.Value).Should(Equal("dark"))
+		Ω(detail.Reason).Should(Equal(openfeature.StaticReason))
+		Ω(detail.FlagMetadata).Should(HaveKeyWithValue(MetadataSourceKey, SourceOverride))
+		Ω(detail.FlagMetadata).Should(HaveKeyWithValue(MetadataOverrideKey, "context"))
+	})
+
+	It("reads overrides written as flag=treatment pairs", func() {
+		detail := subject.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			OverridesAttribute:       "theme=dark, checkout=on",
+		})
+
+		Ω(detail.Value).Should(BeTrue())
+		Ω(detail.Reason).Should(Equal(openfeature.StaticReason))
+	})
+
+	It("evaluates flags that are not overridden without sending the overrides to Split", func() {
+		mockSplitClient.EXPECT().Treatment("user-1", "checkout", map[string]any{"plan": "pro"}).Return("off")
+
+		detail := subject.BooleanEvaluation(context.Background(), "checkout", true, openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			"plan":                   "pro",
+			OverridesAttribute:       map[string]string{"theme": "dark"},
+		})
+
+		Ω(detail.Value).Should(BeFalse())
+		Ω(detail.Reason).Should(Equal(openfeature.TargetingMatchReason))
+	})
+
+	It("ignores the attribute unless enabled", func() {
+		subject, err := NewProvider(mockSplitClient)
+		Ω(err).ShouldNot(HaveOccurred())
+		mockSplitClient.EXPECT().Treatment("user-1", "theme", map[string]any{OverridesAttribute: "theme=dark"}).Return("light")
+
+		detail := subject.StringEvaluation(context.Background(), "theme", "", openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			OverridesAttribute:       "theme=dark",
+		})
+
+		Ω(detail.Value).Should(Equal("light"))
+	})
+
+	It("parses overrides, ignoring malformed pairs", func() {
+		Ω(ParseOverrides("checkout=on, theme = dark,broken,=off,empty=")).Should(Equal(map[string]string{
+			"checkout": "on",
+			"theme":    "dark",
+		}))
+	})
+})
+
+var _ = Describe("Programmatic overrides", func() {
+	var (
+		mockSplitClient *mocks.MockSplitClient
+		subject         *SplitProvider
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		var err error
+		subject, err = NewProvider(mockSplitClient)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	evaluate := func(key string, attributes map[string]any) openfeature.StringResolutionDetail {
+		evalCtx := openfeature.FlattenedContext{openfeature.TargetingKey: key}
+		for name, value := range attributes {
+			evalCtx[name] = value
+		}
+		return subject.StringEvaluation(context.Background(), "theme", "light", evalCtx)
+	}
+
+	It("overrides a flag globally", func() {
+		subject.Override("theme", "dark")
+
+		detail := evaluate("user-1", nil)
+
+		Ω(detail.Value).Should(Equal("dark"))
+		Ω(detail.Reason).Should(Equal(openfeature.StaticReason))
+		Ω(detail.FlagMetadata).Should(Equal(openfeature.FlagMetadata{
+			MetadataSourceKey:   SourceOverride,
+			MetadataOverrideKey: "global",
+		}))
+	})
+
+	It("prefers key overrides, then attribute overrides, then global ones", func() {
+		subject.Override("theme", "dark", ForKey("user-1"))
+		subject.Override("theme", "blue", ForAttribute("plan", "pro"))
+		subject.Override("theme", "green")
+
+		Ω(evaluate("user-1", map[string]any{"plan": "pro"}).Value).Should(Equal("dark"))
+		Ω(evaluate("user-2", map[string]any{"plan": "pro"}).Value).Should(Equal("blue"))
+		Ω(evaluate("user-2", map[string]any{"plan": "pro"}).FlagMetadata).Should(HaveKeyWithValue(MetadataOverrideKey, "attribute"))
+		Ω(evaluate("user-2", map[string]any{"plan": "free"}).Value).Should(Equal("green"))
+	})
+
+	It("serves the latest of equally specific overrides", func() {
+		subject.Override("theme", "dark")
+		subject.Override("theme", "blue")
+
+		Ω(evaluate("user-1", nil).Value).Should(Equal("blue"))
+	})
+
+	It("evaluates with Split once overrides are cleared", func() {
+		mockSplitClient.EXPECT().Treatment("user-1", "theme", nil).Return("light").Times(2)
+		clearDark := subject.Override("theme", "dark")
+		subject.Override("theme", "blue", ForKey("user-2"))
+
+		clearDark()
+		Ω(evaluate("user-1", nil).Value).Should(Equal("light"))
+		Ω(evaluate("user-2", nil).Value).Should(Equal("blue"))
+
+		subject.ClearOverrides()
+		Ω(evaluate("user-1", nil).Reason).Should(Equal(openfeature.TargetingMatchReason))
+	})
+
+	It("clears the overrides of given flags", func() {
+		mockSplitClient.EXPECT().Treatment("user-1", "theme", nil).Return("light")
+		subject.Override("theme", "dark")
+		subject.Override("checkout", "on")
+
+		subject.ClearOverrides("theme")
+
+		Ω(evaluate("user-1", nil).Value).Should(Equal("light"))
+		Ω(subject.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+		}).Value).Should(BeTrue())
+	})
+
+	It("is safe for concurrent use", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				key := "user-" + string(rune('a'+i))
+				clear := subject.Override("theme", key, ForKey(key))
+				Ω(evaluate(key, nil).Value).Should(Equal(key))
+				clear()
+			}()
+		}
+		wg.Wait()
+	})
+})
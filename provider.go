@@ -3,6 +3,7 @@ package fork_split_openfeature_provider_go
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/splitio/go-client/splitio/conf"
 	"strconv"
 
@@ -10,30 +11,130 @@ import (
 	"github.com/splitio/go-client/splitio/client"
 )
 
+const (
+	// MetadataSourceKey is the FlagMetadata key naming where a treatment came from when it was not a live Split evaluation.
+	MetadataSourceKey = "source"
+	// MetadataConfigKey is the FlagMetadata key holding the dynamic configuration attached to a treatment.
+	MetadataConfigKey = "config"
+
+	// SourceFallback marks treatments served from the fallback treatments.
+	SourceFallback = "fallback"
+	// SourceSnapshot marks treatments served from a snapshot while the Split SDK is not ready.
+	SourceSnapshot = "snapshot"
+)
+
 type SplitProvider struct {
-	client ISplitClient
+	client             ISplitClient
+	configClient       ISplitClientWithConfig
+	tracker            ISplitTracker
+	factory            ISplitFactory
+	manager            ISplitManager
+	sticky             *stickyAssignments
+	fallback           map[string]FallbackTreatment
+	snapshot           *snapshots
+	breaker            *circuitBreaker
+	required           []ExpectedFlag
+	trafficTypes       *TrafficTypes
+	keyFallback        []string
+	anonymous          *AnonymousConfig
+	attributeFilter    *attributeFilter
+	overridesAttribute string
+	overrides          overrideSet
+	sdkConfig          []func(cfg *conf.SplitSdkConfig)
+	hooks              []openfeature.Hook
+	events             chan openfeature.Event
 }
 
 var _ openfeature.FeatureProvider = &SplitProvider{}
+var _ openfeature.StateHandler = &SplitProvider{}
+var _ openfeature.EventHandler = &SplitProvider{}
+
+// eventBufferSize bounds the events held for a consumer that is not reading them; further events are dropped.
+const eventBufferSize = 16
 
-func NewProvider(splitClient ISplitClient) (*SplitProvider, error) {
-	return &SplitProvider{
-		client: splitClient,
-	}, nil
+// Option configures optional behaviour of a SplitProvider.
+type Option func(provider *SplitProvider) error
+
+func NewProvider(splitClient ISplitClient, opts ...Option) (*SplitProvider, error) {
+	provider, err := newProvider(opts)
+	if err != nil {
+		return nil, err
+	}
+	provider.setClient(splitClient)
+	provider.startSnapshots()
+	return provider, nil
 }
 
-func NewProviderSimple(apiKey string) (*SplitProvider, error) {
+func NewProviderSimple(apiKey string, opts ...Option) (*SplitProvider, error) {
+	provider, err := newProvider(opts)
+	if err != nil {
+		return nil, err
+	}
 	cfg := conf.Default()
+	for _, configure := range provider.sdkConfig {
+		configure(cfg)
+	}
 	factory, err := client.NewSplitFactory(apiKey, cfg)
 	if err != nil {
 		return nil, err
 	}
 	splitClient := factory.Client()
+	provider.setClient(splitClient)
+	if provider.factory == nil {
+		provider.factory = factory
+	}
+	if provider.manager == nil {
+		provider.manager = factory.Manager()
+	}
+	provider.startSnapshots()
+	if provider.snapshot != nil && provider.snapshot.restored() {
+		// Serve from the snapshot while the SDK synchronizes in the background.
+		return provider, nil
+	}
 	err = splitClient.BlockUntilReady(10)
 	if err != nil {
+		provider.Shutdown()
 		return nil, err
 	}
-	return NewProvider(splitClient)
+	return provider, nil
+}
+
+// newProvider returns a provider configured by the options, without a Split client yet.
+func newProvider(opts []Option) (*SplitProvider, error) {
+	provider := &SplitProvider{
+		events: make(chan openfeature.Event, eventBufferSize),
+	}
+	for _, opt := range opts {
+		if err := opt(provider); err != nil {
+			return nil, err
+		}
+	}
+	if err := provider.validateKeyAttributes(); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+func (provider *SplitProvider) setClient(splitClient ISplitClient) {
+	provider.client = splitClient
+	provider.configClient, _ = splitClient.(ISplitClientWithConfig)
+	provider.tracker, _ = splitClient.(ISplitTracker)
+}
+
+// WithSplitFactory lets the provider observe the readiness of the Split SDK. Without it the client is assumed ready.
+func WithSplitFactory(factory ISplitFactory) Option {
+	return func(provider *SplitProvider) error {
+		provider.factory = factory
+		return nil
+	}
+}
+
+// WithSplitManager gives the provider access to the split definitions known to the Split SDK.
+func WithSplitManager(manager ISplitManager) Option {
+	return func(provider *SplitProvider) error {
+		provider.manager = manager
+		return nil
+	}
 }
 
 func (provider *SplitProvider) Metadata() openfeature.Metadata {
@@ -43,173 +144,223 @@ func (provider *SplitProvider) Metadata() openfeature.Metadata {
 }
 
 func (provider *SplitProvider) BooleanEvaluation(_ context.Context, flag string, defaultValue bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
-	if noTargetingKey(evalCtx) {
-		return openfeature.BoolResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailTargetingKeyMissing(),
-		}
-	}
-	evaluated := provider.evaluateTreatment(flag, evalCtx)
-	if noTreatment(evaluated) {
-		return openfeature.BoolResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailNotFound(evaluated),
-		}
-	}
-	var value bool
-	if evaluated == "true" || evaluated == "on" {
-		value = true
-	} else if evaluated == "false" || evaluated == "off" {
-		value = false
-	} else {
-		return openfeature.BoolResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailParseError(evaluated),
-		}
-	}
+	value, detail := resolve(provider, flag, defaultValue, evalCtx, parseBool)
 	return openfeature.BoolResolutionDetail{
 		Value:                    value,
-		ProviderResolutionDetail: resolutionDetailTargetingMatch(evaluated),
+		ProviderResolutionDetail: detail,
 	}
 }
 
 func (provider *SplitProvider) StringEvaluation(_ context.Context, flag string, defaultValue string, evalCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
-	if noTargetingKey(evalCtx) {
-		return openfeature.StringResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailTargetingKeyMissing(),
-		}
-	}
-	evaluated := provider.evaluateTreatment(flag, evalCtx)
-	if noTreatment(evaluated) {
-		return openfeature.StringResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailNotFound(evaluated),
-		}
-	}
+	value, detail := resolve(provider, flag, defaultValue, evalCtx, parseString)
 	return openfeature.StringResolutionDetail{
-		Value:                    evaluated,
-		ProviderResolutionDetail: resolutionDetailTargetingMatch(evaluated),
+		Value:                    value,
+		ProviderResolutionDetail: detail,
 	}
 }
 
 func (provider *SplitProvider) FloatEvaluation(_ context.Context, flag string, defaultValue float64, evalCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
-	if noTargetingKey(evalCtx) {
-		return openfeature.FloatResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailTargetingKeyMissing(),
-		}
+	value, detail := resolve(provider, flag, defaultValue, evalCtx, parseFloat)
+	return openfeature.FloatResolutionDetail{
+		Value:                    value,
+		ProviderResolutionDetail: detail,
 	}
-	evaluated := provider.evaluateTreatment(flag, evalCtx)
-	if noTreatment(evaluated) {
-		return openfeature.FloatResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailNotFound(evaluated),
-		}
+}
+
+func (provider *SplitProvider) IntEvaluation(_ context.Context, flag string, defaultValue int64, evalCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	value, detail := resolve(provider, flag, defaultValue, evalCtx, parseInt)
+	return openfeature.IntResolutionDetail{
+		Value:                    value,
+		ProviderResolutionDetail: detail,
 	}
-	floatEvaluated, parseErr := strconv.ParseFloat(evaluated, 64)
-	if parseErr != nil {
-		return openfeature.FloatResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailParseError(evaluated),
-		}
+}
+
+func (provider *SplitProvider) ObjectEvaluation(_ context.Context, flag string, defaultValue interface{}, evalCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	value, detail := resolve(provider, flag, defaultValue, evalCtx, parseObject)
+	return openfeature.InterfaceResolutionDetail{
+		Value:                    value,
+		ProviderResolutionDetail: detail,
 	}
-	return openfeature.FloatResolutionDetail{
-		Value:                    floatEvaluated,
-		ProviderResolutionDetail: resolutionDetailTargetingMatch(evaluated),
+}
+
+// Hooks returns the hooks configured with options such as WithEnrichers.
+func (provider *SplitProvider) Hooks() []openfeature.Hook {
+	return append([]openfeature.Hook{}, provider.hooks...)
+}
+
+// Init validates the flags declared with WithRequiredFlags, if any, and reports a provider serving a restored
+// snapshot as stale until the Split SDK is ready.
+func (provider *SplitProvider) Init(_ openfeature.EvaluationContext) error {
+	if err := provider.validateRequiredFlags(); err != nil {
+		return err
 	}
+	provider.watchSnapshot()
+	return nil
 }
 
-func (provider *SplitProvider) IntEvaluation(_ context.Context, flag string, defaultValue int64, evalCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
-	if noTargetingKey(evalCtx) {
-		return openfeature.IntResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailTargetingKeyMissing(),
-		}
+// Shutdown stops background work and, if configured, saves a final snapshot.
+func (provider *SplitProvider) Shutdown() {
+	provider.stopSnapshots()
+}
+
+func (provider *SplitProvider) EventChannel() <-chan openfeature.Event {
+	return provider.events
+}
+
+func (provider *SplitProvider) emit(eventType openfeature.EventType, message string) {
+	event := openfeature.Event{
+		ProviderName: provider.Metadata().Name,
+		EventType:    eventType,
+		ProviderEventDetails: openfeature.ProviderEventDetails{
+			Message: message,
+		},
 	}
-	evaluated := provider.evaluateTreatment(flag, evalCtx)
-	if noTreatment(evaluated) {
-		return openfeature.IntResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailNotFound(evaluated),
-		}
+	select {
+	case provider.events <- event:
+	default:
 	}
-	intEvaluated, parseErr := strconv.ParseInt(evaluated, 10, 64)
-	if parseErr != nil {
-		return openfeature.IntResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailParseError(evaluated),
-		}
+}
+
+// *** Helpers ***
+
+// evaluation is the treatment resolved for a flag before it is converted to the requested type.
+type evaluation struct {
+	treatment string
+	reason    openfeature.Reason
+	metadata  openfeature.FlagMetadata
+	// err explains why no treatment was resolved, when it was not simply because the flag was not found.
+	err *openfeature.ResolutionError
+}
+
+// resolve evaluates the flag and converts its treatment with parse. It returns the default value when the
+// context has no Split key for the flag, no treatment was resolved or the treatment does not parse.
+func resolve[T any](provider *SplitProvider, flag string, defaultValue T, evalCtx openfeature.FlattenedContext, parse func(string) (T, bool)) (T, openfeature.ProviderResolutionDetail) {
+	targetKey, synthetic, keyErr := provider.splitKey(flag, evalCtx)
+	if keyErr != nil {
+		return defaultValue, providerResolutionDetailError(*keyErr, openfeature.ErrorReason, "")
 	}
-	return openfeature.IntResolutionDetail{
-		Value:                    intEvaluated,
-		ProviderResolutionDetail: resolutionDetailTargetingMatch(evaluated),
+	evaluated := provider.evaluateTreatment(flag, targetKey, evalCtx)
+	if synthetic {
+		evaluated.metadata = withSyntheticKey(evaluated.metadata)
+	}
+	if noTreatment(evaluated.treatment) {
+		return defaultValue, resolutionDetailNoTreatment(evaluated)
 	}
+	value, ok := parse(evaluated.treatment)
+	if !ok {
+		return defaultValue, resolutionDetailParseError(evaluated.treatment)
+	}
+	return value, resolutionDetailResolved(evaluated)
 }
 
-func (provider *SplitProvider) ObjectEvaluation(_ context.Context, flag string, defaultValue interface{}, evalCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
-	if noTargetingKey(evalCtx) {
-		return openfeature.InterfaceResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailTargetingKeyMissing(),
+func (provider *SplitProvider) evaluateTreatment(flag string, targetKey any, evalContext openfeature.FlattenedContext) evaluation {
+	if override, ok := provider.overrideEvaluation(flag, evalContext); ok {
+		return override
+	}
+	if override, ok := provider.programmaticEvaluation(flag, targetKey, evalContext); ok {
+		return override
+	}
+	if assignment, ok := provider.sticky.lookup(flag, targetKey); ok {
+		cached := evaluation{treatment: assignment.Treatment, reason: openfeature.CachedReason}
+		if assignment.Config != nil {
+			cached.metadata = openfeature.FlagMetadata{
+				MetadataConfigKey: *assignment.Config,
+			}
 		}
+		return cached
+	}
+	if stale, ok := provider.snapshotEvaluation(flag, targetKey); ok {
+		return stale
 	}
-	evaluated := provider.evaluateTreatment(flag, evalCtx)
-	if noTreatment(evaluated) {
-		return openfeature.InterfaceResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailNotFound(evaluated),
+	attributes := provider.splitAttributes(evalContext)
+	treatment, config, called, err := provider.guardedTreatment(targetKey, flag, attributes)
+	if noTreatment(treatment) {
+		if fallback, ok := provider.fallbackEvaluation(flag); ok {
+			return fallback
+		}
+		if !called {
+			return evaluation{treatment: treatment, reason: openfeature.DefaultReason, err: &errCircuitOpen}
+		}
+		if err != nil {
+			return evaluation{treatment: treatment, reason: openfeature.ErrorReason, err: err}
 		}
+		return evaluation{treatment: treatment}
 	}
-	var data map[string]interface{}
-	parseErr := json.Unmarshal([]byte(evaluated), &data)
-	if parseErr != nil {
-		return openfeature.InterfaceResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: resolutionDetailParseError(evaluated),
+	provider.sticky.pin(flag, targetKey, treatment, config)
+	provider.observeSnapshot(flag, targetKey, treatment)
+	evaluated := evaluation{treatment: treatment, reason: openfeature.TargetingMatchReason}
+	if config != nil {
+		evaluated.metadata = openfeature.FlagMetadata{
+			MetadataConfigKey: *config,
 		}
-	} else {
-		return openfeature.InterfaceResolutionDetail{
-			Value:                    data,
-			ProviderResolutionDetail: resolutionDetailTargetingMatch(evaluated),
+	}
+	return evaluated
+}
+
+// splitTreatment asks the Split client for the treatment, and its configuration when the client supports it.
+// A panicking client is reported as control with a GENERAL resolution error.
+func (provider *SplitProvider) splitTreatment(key any, flag string, attributes map[string]any) (treatment string, config *string, err *openfeature.ResolutionError) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			panicErr := openfeature.NewGeneralResolutionError(fmt.Sprintf("Split client panicked: %v", recovered))
+			treatment, config, err = "control", nil, &panicErr
 		}
+	}()
+	if provider.configClient != nil {
+		result := provider.configClient.TreatmentWithConfig(key, flag, attributes)
+		return result.Treatment, result.Config, nil
 	}
+	return provider.client.Treatment(key, flag, attributes), nil, nil
+}
 
+func (provider *SplitProvider) isReady() bool {
+	return provider.factory == nil || provider.factory.IsReady()
 }
 
-func (provider *SplitProvider) Hooks() []openfeature.Hook {
-	return []openfeature.Hook{}
+// parseBool converts the treatments Split conventionally uses for boolean flags.
+func parseBool(treatment string) (bool, bool) {
+	switch treatment {
+	case "true", "on":
+		return true, true
+	case "false", "off":
+		return false, true
+	default:
+		return false, false
+	}
 }
 
-// *** Helpers ***
+func parseString(treatment string) (string, bool) {
+	return treatment, true
+}
 
-func (provider *SplitProvider) evaluateTreatment(flag string, evalContext openfeature.FlattenedContext) string {
-	var (
-		targetKey  any
-		attributes = map[string]any{}
-	)
-	for key, value := range evalContext {
-		if key == openfeature.TargetingKey {
-			targetKey = value
-		} else {
-			attributes[key] = value
-		}
-	}
-	if len(attributes) == 0 {
-		attributes = nil
-	}
-	return provider.client.Treatment(targetKey, flag, attributes)
+func parseInt(treatment string) (int64, bool) {
+	value, err := strconv.ParseInt(treatment, 10, 64)
+	return value, err == nil
 }
 
-func noTargetingKey(evalContext openfeature.FlattenedContext) bool {
-	_, ok := evalContext[openfeature.TargetingKey]
-	return !ok
+func parseFloat(treatment string) (float64, bool) {
+	value, err := strconv.ParseFloat(treatment, 64)
+	return value, err == nil
+}
+
+// parseObject decodes a JSON object treatment.
+func parseObject(treatment string) (interface{}, bool) {
+	var data map[string]interface{}
+	err := json.Unmarshal([]byte(treatment), &data)
+	return data, err == nil
 }
 
 func noTreatment(treatment string) bool {
 	return treatment == "" || treatment == "control"
 }
 
+func resolutionDetailNoTreatment(evaluated evaluation) openfeature.ProviderResolutionDetail {
+	if evaluated.err != nil {
+		return providerResolutionDetailError(*evaluated.err, evaluated.reason, evaluated.treatment)
+	}
+	return resolutionDetailNotFound(evaluated.treatment)
+}
+
 func resolutionDetailNotFound(variant string) openfeature.ProviderResolutionDetail {
 	return providerResolutionDetailError(
 		openfeature.NewFlagNotFoundResolutionError(
@@ -225,13 +376,6 @@ func resolutionDetailParseError(variant string) openfeature.ProviderResolutionDe
 		variant)
 }
 
-func resolutionDetailTargetingKeyMissing() openfeature.ProviderResolutionDetail {
-	return providerResolutionDetailError(
-		openfeature.NewTargetingKeyMissingResolutionError("Targeting key is required and missing."),
-		openfeature.ErrorReason,
-		"")
-}
-
 func providerResolutionDetailError(error openfeature.ResolutionError, reason openfeature.Reason, variant string) openfeature.ProviderResolutionDetail {
 	return openfeature.ProviderResolutionDetail{
 		ResolutionError: error,
@@ -240,9 +384,10 @@ func providerResolutionDetailError(error openfeature.ResolutionError, reason ope
 	}
 }
 
-func resolutionDetailTargetingMatch(variant string) openfeature.ProviderResolutionDetail {
+func resolutionDetailResolved(evaluated evaluation) openfeature.ProviderResolutionDetail {
 	return openfeature.ProviderResolutionDetail{
-		Reason:  openfeature.TargetingMatchReason,
-		Variant: variant,
+		Reason:       evaluated.reason,
+		Variant:      evaluated.treatment,
+		FlagMetadata: evaluated.metadata,
 	}
 }
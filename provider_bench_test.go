@@ -0,0 +1,76 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+)
+
+// benchmarkContextSizes are the numbers of attributes, besides the targeting key, of the benchmarked contexts.
+var benchmarkContextSizes = []int{0, 1, 10, 50}
+
+func benchmarkContext(attributes int) openfeature.FlattenedContext {
+	evalCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "user-1"}
+	for i := 0; i < attributes; i++ {
+		evalCtx[fmt.Sprintf("attribute-%d", i)] = i
+	}
+	return evalCtx
+}
+
+// benchmarkEvaluation runs evaluate for each context size against a Split client serving treatment.
+func benchmarkEvaluation(b *testing.B, treatment string, evaluate func(provider *SplitProvider, evalCtx openfeature.FlattenedContext)) {
+	provider, err := NewProvider(treatmentFunc(func(any, string, map[string]any) string {
+		return treatment
+	}))
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, size := range benchmarkContextSizes {
+		evalCtx := benchmarkContext(size)
+		b.Run(fmt.Sprintf("attributes=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				evaluate(provider, evalCtx)
+			}
+		})
+	}
+}
+
+func BenchmarkBooleanEvaluation(b *testing.B) {
+	benchmarkEvaluation(b, "on", func(provider *SplitProvider, evalCtx openfeature.FlattenedContext) {
+		provider.BooleanEvaluation(context.Background(), "flag", false, evalCtx)
+	})
+}
+
+func BenchmarkStringEvaluation(b *testing.B) {
+	benchmarkEvaluation(b, "blue", func(provider *SplitProvider, evalCtx openfeature.FlattenedContext) {
+		provider.StringEvaluation(context.Background(), "flag", "", evalCtx)
+	})
+}
+
+func BenchmarkIntEvaluation(b *testing.B) {
+	benchmarkEvaluation(b, "42", func(provider *SplitProvider, evalCtx openfeature.FlattenedContext) {
+		provider.IntEvaluation(context.Background(), "flag", 0, evalCtx)
+	})
+}
+
+func BenchmarkFloatEvaluation(b *testing.B) {
+	benchmarkEvaluation(b, "0.5", func(provider *SplitProvider, evalCtx openfeature.FlattenedContext) {
+		provider.FloatEvaluation(context.Background(), "flag", 0, evalCtx)
+	})
+}
+
+func BenchmarkObjectEvaluation(b *testing.B) {
+	benchmarkEvaluation(b, `{"color":"red","sizes":[1,2]}`, func(provider *SplitProvider, evalCtx openfeature.FlattenedContext) {
+		provider.ObjectEvaluation(context.Background(), "flag", nil, evalCtx)
+	})
+}
+
+func BenchmarkFlagNotFound(b *testing.B) {
+	benchmarkEvaluation(b, "control", func(provider *SplitProvider, evalCtx openfeature.FlattenedContext) {
+		provider.BooleanEvaluation(context.Background(), "flag", false, evalCtx)
+	})
+}
@@ -0,0 +1,145 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+)
+
+// treatmentFunc adapts a function to the Split client interface.
+type treatmentFunc func(key any, feature string, attributes map[string]any) string
+
+func (f treatmentFunc) Treatment(key any, feature string, attributes map[string]any) string {
+	return f(key, feature, attributes)
+}
+
+// fuzzProvider returns a provider whose Split client serves treatment, failing t if the provider passes the
+// targeting key along with the attributes.
+func fuzzProvider(t *testing.T, treatment string) *SplitProvider {
+	provider, err := NewProvider(treatmentFunc(func(_ any, _ string, attributes map[string]any) string {
+		if _, ok := attributes[openfeature.TargetingKey]; ok {
+			t.Fatalf("targeting key passed as an attribute: %v", attributes)
+		}
+		if attributes != nil && len(attributes) == 0 {
+			t.Fatal("empty attributes passed instead of nil")
+		}
+		return treatment
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+// fuzzContext decodes an evaluation context from JSON, so the fuzzer can vary both its shape and the type of
+// the targeting key. Input that is not a JSON object is used as the targeting key.
+func fuzzContext(data []byte) openfeature.FlattenedContext {
+	var evalCtx openfeature.FlattenedContext
+	if err := json.Unmarshal(data, &evalCtx); err != nil || evalCtx == nil {
+		return openfeature.FlattenedContext{openfeature.TargetingKey: string(data)}
+	}
+	return evalCtx
+}
+
+// checkResolution asserts the invariants every resolution must satisfy: an error comes with the default value
+// and an ERROR or DEFAULT reason, and a resolved value comes with a non-error reason and the treatment as variant.
+func checkResolution(t *testing.T, detail openfeature.ProviderResolutionDetail, treatment string, isDefault bool) {
+	t.Helper()
+	if detail.Error() != nil {
+		if !isDefault {
+			t.Fatalf("error %v returned without the default value", detail.Error())
+		}
+		if detail.Reason != openfeature.ErrorReason && detail.Reason != openfeature.DefaultReason {
+			t.Fatalf("error %v returned with reason %s", detail.Error(), detail.Reason)
+		}
+		return
+	}
+	if detail.Reason == "" || detail.Reason == openfeature.ErrorReason {
+		t.Fatalf("resolved without error but with reason %q", detail.Reason)
+	}
+	if detail.Variant != treatment {
+		t.Fatalf("resolved variant %q for treatment %q", detail.Variant, treatment)
+	}
+}
+
+func addFuzzSeeds(f *testing.F) {
+	for _, treatment := range []string{"on", "off", "true", "control", "", "42", "-1.5e3", "NaN", "0x10", `{"a":[1,2]}`, `[1]`, "null"} {
+		f.Add(treatment, []byte(`{"targetingKey":"user-1"}`))
+	}
+	f.Add("on", []byte(`{}`))
+	f.Add("on", []byte(`{"targetingKey":null,"plan":"pro"}`))
+	f.Add("on", []byte(`{"targetingKey":7,"nested":{"a":[true,1.5]}}`))
+	f.Add("on", []byte(`not json`))
+}
+
+func FuzzBooleanEvaluation(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, treatment string, data []byte) {
+		detail := fuzzProvider(t, treatment).BooleanEvaluation(context.Background(), "flag", true, fuzzContext(data))
+
+		checkResolution(t, detail.ProviderResolutionDetail, treatment, detail.Value == true)
+		if detail.Error() == nil && detail.Value != (treatment == "on" || treatment == "true") {
+			t.Fatalf("treatment %q resolved to %v", treatment, detail.Value)
+		}
+	})
+}
+
+func FuzzStringEvaluation(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, treatment string, data []byte) {
+		detail := fuzzProvider(t, treatment).StringEvaluation(context.Background(), "flag", "default", fuzzContext(data))
+
+		checkResolution(t, detail.ProviderResolutionDetail, treatment, detail.Value == "default")
+		if detail.Error() == nil && detail.Value != treatment {
+			t.Fatalf("treatment %q resolved to %q", treatment, detail.Value)
+		}
+	})
+}
+
+func FuzzIntEvaluation(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, treatment string, data []byte) {
+		detail := fuzzProvider(t, treatment).IntEvaluation(context.Background(), "flag", 13, fuzzContext(data))
+
+		checkResolution(t, detail.ProviderResolutionDetail, treatment, detail.Value == 13)
+		if detail.Error() == nil {
+			if parsed, err := strconv.ParseInt(treatment, 10, 64); err != nil || parsed != detail.Value {
+				t.Fatalf("treatment %q resolved to %d", treatment, detail.Value)
+			}
+		}
+	})
+}
+
+func FuzzFloatEvaluation(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, treatment string, data []byte) {
+		detail := fuzzProvider(t, treatment).FloatEvaluation(context.Background(), "flag", 0.25, fuzzContext(data))
+
+		checkResolution(t, detail.ProviderResolutionDetail, treatment, detail.Value == 0.25)
+		if detail.Error() == nil {
+			if parsed, err := strconv.ParseFloat(treatment, 64); err != nil || (parsed != detail.Value && !math.IsNaN(parsed)) {
+				t.Fatalf("treatment %q resolved to %v", treatment, detail.Value)
+			}
+		}
+	})
+}
+
+func FuzzObjectEvaluation(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, treatment string, data []byte) {
+		defaultValue := &struct{}{}
+		detail := fuzzProvider(t, treatment).ObjectEvaluation(context.Background(), "flag", defaultValue, fuzzContext(data))
+
+		checkResolution(t, detail.ProviderResolutionDetail, treatment, detail.Value == defaultValue)
+		if detail.Error() == nil {
+			if _, ok := detail.Value.(map[string]any); !ok {
+				t.Fatalf("treatment %q resolved to %T", treatment, detail.Value)
+			}
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/open-feature/go-sdk/openfeature"
 	. "github.com/snap-one/fork-split-openfeature-provider-go"
 	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"github.com/splitio/go-client/splitio/client"
 	"go.uber.org/mock/gomock"
 )
 
@@ -171,6 +172,28 @@ var _ = Describe("Provider", func() {
 		})
 	})
 
+	It("reports a panicking Split client as a general error", func() {
+		key := uuid.NewString()
+		feature := uuid.NewString()
+		mockSplitClient.EXPECT().Treatment(key, feature, nil).DoAndReturn(func(any, string, map[string]any) string {
+			panic("redis unavailable")
+		})
+
+		// act
+		result := subject.BooleanEvaluation(context.Background(), feature, true, openfeature.FlattenedContext{
+			openfeature.TargetingKey: key,
+		})
+
+		Ω(result).Should(Equal(openfeature.BoolResolutionDetail{
+			Value: true,
+			ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+				ResolutionError: openfeature.NewGeneralResolutionError("Split client panicked: redis unavailable"),
+				Reason:          openfeature.ErrorReason,
+				Variant:         "control",
+			},
+		}))
+	})
+
 	Describe("StringEvaluation", func() {
 		It("should return the default value and error if no targeting key", func() {
 			feature := uuid.NewString()
@@ -658,6 +681,40 @@ var _ = Describe("Provider", func() {
 		})
 	})
 
+	Describe("treatment configs", func() {
+		It("reports the config in the flag metadata when the client supports it", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigClient := mocks.NewMockSplitClientWithConfig(mockCtrl)
+			subject, err := NewProvider(struct {
+				*mocks.MockSplitClient
+				*mocks.MockSplitClientWithConfig
+			}{mockSplitClient, mockConfigClient})
+			Ω(err).ShouldNot(HaveOccurred())
+			key := uuid.NewString()
+			feature := uuid.NewString()
+			config := `{"color":"red"}`
+			mockConfigClient.EXPECT().
+				TreatmentWithConfig(key, feature, nil).
+				Return(client.TreatmentResult{Treatment: "on", Config: &config})
+
+			// act
+			result := subject.BooleanEvaluation(context.Background(), feature, false, openfeature.FlattenedContext{
+				openfeature.TargetingKey: key,
+			})
+
+			Ω(result).Should(Equal(openfeature.BoolResolutionDetail{
+				Value: true,
+				ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+					Reason:  openfeature.TargetingMatchReason,
+					Variant: "on",
+					FlagMetadata: openfeature.FlagMetadata{
+						MetadataConfigKey: config,
+					},
+				},
+			}))
+		})
+	})
+
 	Describe("NewProviderSimple", Ordered, func() {
 		It("successfully creates a new provider", func() {
 			Ω(NewProviderSimple("localhost")).ShouldNot(BeNil())
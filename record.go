@@ -0,0 +1,226 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/splitio/go-client/splitio/client"
+)
+
+// Recording is one Split evaluation captured by a RecordingClient, stored as a line of JSON.
+type Recording struct {
+	Flag       string         `json:"flag"`
+	Key        any            `json:"key"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	Treatment  string         `json:"treatment"`
+	Config     *string        `json:"config,omitempty"`
+}
+
+// RecordingClient is a Split client that records every evaluation made through the client it wraps.
+// Pass it to NewProvider in place of the Split client to capture real traffic for ReplayClient and Replay.
+type RecordingClient struct {
+	client ISplitClient
+	mu     sync.Mutex
+	out    *json.Encoder
+	err    error
+}
+
+// NewRecordingClient returns a RecordingClient writing the evaluations of splitClient to out as JSON lines.
+func NewRecordingClient(splitClient ISplitClient, out io.Writer) *RecordingClient {
+	return &RecordingClient{
+		client: splitClient,
+		out:    json.NewEncoder(out),
+	}
+}
+
+// Treatment evaluates the flag with the wrapped client and records the result.
+func (recorder *RecordingClient) Treatment(key any, feature string, attributes map[string]any) string {
+	return recorder.TreatmentWithConfig(key, feature, attributes).Treatment
+}
+
+// TreatmentWithConfig evaluates the flag with the wrapped client and records the result, including the
+// treatment config when the wrapped client returns configs.
+func (recorder *RecordingClient) TreatmentWithConfig(key any, feature string, attributes map[string]any) client.TreatmentResult {
+	var result client.TreatmentResult
+	if configClient, ok := recorder.client.(ISplitClientWithConfig); ok {
+		result = configClient.TreatmentWithConfig(key, feature, attributes)
+	} else {
+		result.Treatment = recorder.client.Treatment(key, feature, attributes)
+	}
+	recorder.record(Recording{
+		Flag:       feature,
+		Key:        key,
+		Attributes: attributes,
+		Treatment:  result.Treatment,
+		Config:     result.Config,
+	})
+	return result
+}
+
+func (recorder *RecordingClient) record(recording Recording) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	// Recording is best effort: a write failure should not change the treatment being served.
+	if err := recorder.out.Encode(recording); err != nil && recorder.err == nil {
+		recorder.err = err
+	}
+}
+
+// Err returns the first error encountered writing a recording, if any.
+func (recorder *RecordingClient) Err() error {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return recorder.err
+}
+
+// ReadRecordings reads the JSON lines written by a RecordingClient.
+func ReadRecordings(in io.Reader) ([]Recording, error) {
+	var recordings []Recording
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var recording Recording
+		if err := json.Unmarshal(scanner.Bytes(), &recording); err != nil {
+			return nil, fmt.Errorf("recording line %d: %w", line, err)
+		}
+		recordings = append(recordings, recording)
+	}
+	return recordings, scanner.Err()
+}
+
+// ReadRecordingFile reads the recordings written to path by a RecordingClient.
+func ReadRecordingFile(path string) ([]Recording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadRecordings(file)
+}
+
+// ReplayClient is a Split client serving the treatments of recorded evaluations. An evaluation matches a
+// recording with the same flag, key and attributes; when several do, the last one is served. Evaluations
+// without a matching recording get the control treatment.
+type ReplayClient struct {
+	results map[string]client.TreatmentResult
+}
+
+// NewReplayClient returns a ReplayClient serving the given recordings.
+func NewReplayClient(recordings []Recording) *ReplayClient {
+	replay := &ReplayClient{results: make(map[string]client.TreatmentResult, len(recordings))}
+	for _, recording := range recordings {
+		replay.results[recordingKey(recording.Flag, recording.Key, recording.Attributes)] = client.TreatmentResult{
+			Treatment: recording.Treatment,
+			Config:    recording.Config,
+		}
+	}
+	return replay
+}
+
+// Treatment returns the recorded treatment of the evaluation.
+func (replay *ReplayClient) Treatment(key any, feature string, attributes map[string]any) string {
+	return replay.TreatmentWithConfig(key, feature, attributes).Treatment
+}
+
+// TreatmentWithConfig returns the recorded treatment and config of the evaluation.
+func (replay *ReplayClient) TreatmentWithConfig(key any, feature string, attributes map[string]any) client.TreatmentResult {
+	result, ok := replay.results[recordingKey(feature, key, attributes)]
+	if !ok {
+		return client.TreatmentResult{Treatment: "control"}
+	}
+	return result
+}
+
+// recordingKey identifies an evaluation by its JSON encoding, so that values which only differ in their Go
+// type after a round trip through a recording, such as 3 and 3.0, still match.
+func recordingKey(flag string, key any, attributes map[string]any) string {
+	if len(attributes) == 0 {
+		attributes = nil
+	}
+	encoded, err := json.Marshal([]any{flag, key, attributes})
+	if err != nil {
+		return fmt.Sprint(flag, key, attributes)
+	}
+	return string(encoded)
+}
+
+// ReplayDifference is a recorded evaluation for which the replayed client returned another result.
+type ReplayDifference struct {
+	Recording Recording
+	Treatment string
+	Config    *string
+}
+
+func (difference ReplayDifference) String() string {
+	return fmt.Sprintf("flag %q for key %v: recorded %s, replayed %s",
+		difference.Recording.Flag, difference.Recording.Key,
+		describeResult(difference.Recording.Treatment, difference.Recording.Config),
+		describeResult(difference.Treatment, difference.Config))
+}
+
+func describeResult(treatment string, config *string) string {
+	if config == nil {
+		return fmt.Sprintf("%q", treatment)
+	}
+	return fmt.Sprintf("%q with config %s", treatment, *config)
+}
+
+// ReplayReport is the outcome of replaying recorded evaluations against a Split client.
+type ReplayReport struct {
+	Replayed    int
+	Differences []ReplayDifference
+}
+
+// OK reports whether every replayed evaluation returned the recorded result.
+func (report ReplayReport) OK() bool {
+	return len(report.Differences) == 0
+}
+
+// Err returns an error describing every difference, or nil when the report is OK.
+func (report ReplayReport) Err() error {
+	if report.OK() {
+		return nil
+	}
+	differences := make([]string, 0, len(report.Differences))
+	for _, difference := range report.Differences {
+		differences = append(differences, difference.String())
+	}
+	return fmt.Errorf("%d of %d replayed evaluations differ: %s",
+		len(report.Differences), report.Replayed, strings.Join(differences, "; "))
+}
+
+// Replay evaluates every recording again with splitClient, for example a client reading a localhost file or
+// built from a new version of a split, and reports the evaluations whose treatment or config changed.
+// Configs are only compared when splitClient returns them.
+func Replay(recordings []Recording, splitClient ISplitClient) ReplayReport {
+	report := ReplayReport{Replayed: len(recordings)}
+	configClient, withConfig := splitClient.(ISplitClientWithConfig)
+	for _, recording := range recordings {
+		var result client.TreatmentResult
+		if withConfig {
+			result = configClient.TreatmentWithConfig(recording.Key, recording.Flag, recording.Attributes)
+		} else {
+			result = client.TreatmentResult{
+				Treatment: splitClient.Treatment(recording.Key, recording.Flag, recording.Attributes),
+				Config:    recording.Config,
+			}
+		}
+		if result.Treatment != recording.Treatment || !reflect.DeepEqual(result.Config, recording.Config) {
+			report.Differences = append(report.Differences, ReplayDifference{
+				Recording: recording,
+				Treatment: result.Treatment,
+				Config:    result.Config,
+			})
+		}
+	}
+	return report
+}
@@ -0,0 +1,118 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"github.com/splitio/go-client/splitio/client"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Record and replay", func() {
+	var (
+		mockSplitClient *mocks.MockSplitClient
+		out             *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		out = &bytes.Buffer{}
+	})
+
+	record := func() []Recording {
+		recordings, err := ReadRecordings(out)
+		Ω(err).ShouldNot(HaveOccurred())
+		return recordings
+	}
+
+	It("records the evaluations made through the provider", func() {
+		recorder := NewRecordingClient(mockSplitClient, out)
+		subject, err := NewProvider(recorder)
+		Ω(err).ShouldNot(HaveOccurred())
+		mockSplitClient.EXPECT().Treatment("user-1", "checkout", map[string]any{"plan": "pro", "seats": int64(3)}).Return("on")
+		mockSplitClient.EXPECT().Treatment("user-2", "limit", nil).Return("25")
+
+		subject.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			"plan":                   "pro",
+			"seats":                  int64(3),
+		})
+		subject.IntEvaluation(context.Background(), "limit", 0, openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-2",
+		})
+
+		Ω(recorder.Err()).ShouldNot(HaveOccurred())
+		Ω(record()).Should(Equal([]Recording{
+			{Flag: "checkout", Key: "user-1", Attributes: map[string]any{"plan": "pro", "seats": float64(3)}, Treatment: "on"},
+			{Flag: "limit", Key: "user-2", Treatment: "25"},
+		}))
+	})
+
+	It("records treatment configs when the client returns them", func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		configClient := mocks.NewMockSplitClientWithConfig(mockCtrl)
+		config := `{"color":"red"}`
+		configClient.EXPECT().
+			TreatmentWithConfig("user-1", "checkout", nil).
+			Return(client.TreatmentResult{Treatment: "on", Config: &config})
+		recorder := NewRecordingClient(struct {
+			*mocks.MockSplitClient
+			*mocks.MockSplitClientWithConfig
+		}{mockSplitClient, configClient}, out)
+
+		Ω(recorder.Treatment("user-1", "checkout", nil)).Should(Equal("on"))
+
+		Ω(record()).Should(Equal([]Recording{{Flag: "checkout", Key: "user-1", Treatment: "on", Config: &config}}))
+	})
+
+	It("serves recorded evaluations and control for unknown ones", func() {
+		config := `{"color":"red"}`
+		recordings, err := ReadRecordings(strings.NewReader(`{"flag":"checkout","key":"user-1","attributes":{"seats":3},"treatment":"on","config":"{\"color\":\"red\"}"}
+
+{"flag":"checkout","key":"user-2","treatment":"off"}
+`))
+		Ω(err).ShouldNot(HaveOccurred())
+		replay := NewReplayClient(recordings)
+
+		Ω(replay.TreatmentWithConfig("user-1", "checkout", map[string]any{"seats": int64(3)})).Should(Equal(client.TreatmentResult{Treatment: "on", Config: &config}))
+		Ω(replay.Treatment("user-2", "checkout", map[string]any{})).Should(Equal("off"))
+		Ω(replay.Treatment("user-3", "checkout", nil)).Should(Equal("control"))
+		Ω(replay.Treatment("user-1", "checkout", nil)).Should(Equal("control"))
+	})
+
+	It("reports the line of an invalid recording", func() {
+		_, err := ReadRecordings(strings.NewReader("{\"flag\":\"checkout\"}\nnot json\n"))
+		Ω(err).Should(MatchError(ContainSubstring("recording line 2")))
+	})
+
+	It("reports the evaluations whose result changed", func() {
+		recordings := []Recording{
+			{Flag: "checkout", Key: "user-1", Treatment: "on"},
+			{Flag: "checkout", Key: "user-2", Treatment: "off"},
+		}
+		mockSplitClient.EXPECT().Treatment("user-1", "checkout", nil).Return("on")
+		mockSplitClient.EXPECT().Treatment("user-2", "checkout", nil).Return("on")
+
+		report := Replay(recordings, mockSplitClient)
+
+		Ω(report.OK()).Should(BeFalse())
+		Ω(report.Differences).Should(Equal([]ReplayDifference{{Recording: recordings[1], Treatment: "on"}}))
+		Ω(report.Err()).Should(MatchError(`1 of 2 replayed evaluations differ: flag "checkout" for key user-2: recorded "off", replayed "on"`))
+	})
+
+	It("matches a replay of its own recordings", func() {
+		recordings := []Recording{{Flag: "checkout", Key: "user-1", Attributes: map[string]any{"seats": float64(3)}, Treatment: "on"}}
+
+		report := Replay(recordings, NewReplayClient(recordings))
+
+		Ω(report.OK()).Should(BeTrue())
+		Ω(report.Err()).ShouldNot(HaveOccurred())
+	})
+})
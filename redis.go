@@ -0,0 +1,43 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"errors"
+
+	"github.com/splitio/go-client/splitio/conf"
+)
+
+// RedisConfig locates the Redis instance a Split synchronizer keeps the split definitions in.
+type RedisConfig struct {
+	// Host defaults to localhost.
+	Host string
+	// Port defaults to 6379.
+	Port     int
+	Database int
+	Password string
+	// Prefix is the key prefix the synchronizer was configured with, if any.
+	Prefix string
+}
+
+// WithRedisConsumer makes NewProviderSimple run the Split SDK in consumer mode, evaluating the split definitions
+// a Split synchronizer writes to Redis instead of fetching them from Split. It has no effect on NewProvider, which
+// takes an already built Split client.
+func WithRedisConsumer(config RedisConfig) Option {
+	return func(provider *SplitProvider) error {
+		if config.Port < 0 || config.Database < 0 {
+			return errors.New("redis port and database must not be negative")
+		}
+		provider.sdkConfig = append(provider.sdkConfig, func(cfg *conf.SplitSdkConfig) {
+			cfg.OperationMode = conf.RedisConsumer
+			if config.Host != "" {
+				cfg.Redis.Host = config.Host
+			}
+			if config.Port != 0 {
+				cfg.Redis.Port = config.Port
+			}
+			cfg.Redis.Database = config.Database
+			cfg.Redis.Password = config.Password
+			cfg.Redis.Prefix = config.Prefix
+		})
+		return nil
+	}
+}
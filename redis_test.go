@@ -0,0 +1,106 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/alicebob/miniredis/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+)
+
+// checkoutSplit is the checkout split as a Split synchronizer stores it: on for the pro plan, off otherwise.
+const checkoutSplit = `{
+	"name": "checkout",
+	"trafficTypeName": "user",
+	"status": "ACTIVE",
+	"defaultTreatment": "off",
+	"changeNumber": 1700000000000,
+	"seed": 1,
+	"algo": 2,
+	"trafficAllocation": 100,
+	"trafficAllocationSeed": 1,
+	"conditions": [
+		{
+			"conditionType": "WHITELIST",
+			"matcherGroup": {
+				"combiner": "AND",
+				"matchers": [{
+					"keySelector": {"trafficType": "user", "attribute": "plan"},
+					"matcherType": "WHITELIST",
+					"whitelistMatcherData": {"whitelist": ["pro"]}
+				}]
+			},
+			"partitions": [{"treatment": "on", "size": 100}],
+			"label": "pro plan"
+		},
+		{
+			"conditionType": "ROLLOUT",
+			"matcherGroup": {
+				"combiner": "AND",
+				"matchers": [{"keySelector": {"trafficType": "user"}, "matcherType": "ALL_KEYS"}]
+			},
+			"partitions": [{"treatment": "off", "size": 100}],
+			"label": "default rule"
+		}
+	]
+}`
+
+var _ = Describe("Redis consumer mode", func() {
+	var server *miniredis.Miniredis
+
+	BeforeEach(func() {
+		server = miniredis.RunT(GinkgoT())
+	})
+
+	newRedisProvider := func(prefix string) *SplitProvider {
+		port, err := strconv.Atoi(server.Port())
+		Ω(err).ShouldNot(HaveOccurred())
+		provider, err := NewProviderSimple("sdk-key", WithRedisConsumer(RedisConfig{
+			Host:   server.Host(),
+			Port:   port,
+			Prefix: prefix,
+		}))
+		Ω(err).ShouldNot(HaveOccurred())
+		DeferCleanup(provider.Shutdown)
+		return provider
+	}
+
+	evaluate := func(provider *SplitProvider, plan string) openfeature.BoolResolutionDetail {
+		return provider.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			"plan":                   plan,
+		})
+	}
+
+	It("evaluates the splits a synchronizer stored in Redis", func() {
+		Ω(server.Set("SPLITIO.split.checkout", checkoutSplit)).Should(Succeed())
+		Ω(server.Set("SPLITIO.splits.till", "1700000000000")).Should(Succeed())
+		provider := newRedisProvider("")
+
+		Ω(evaluate(provider, "pro").Value).Should(BeTrue())
+		free := evaluate(provider, "free")
+		Ω(free.Value).Should(BeFalse())
+		Ω(free.Variant).Should(Equal("off"))
+		Ω(free.Reason).Should(Equal(openfeature.TargetingMatchReason))
+		flag, ok, err := provider.Flag("checkout")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(ok).Should(BeTrue())
+		Ω(flag.Treatments).Should(ConsistOf("on", "off"))
+	})
+
+	It("reads the keys under the configured prefix", func() {
+		Ω(server.Set("staging.SPLITIO.split.checkout", checkoutSplit)).Should(Succeed())
+		provider := newRedisProvider("staging")
+
+		Ω(evaluate(provider, "pro").Value).Should(BeTrue())
+	})
+
+	It("rejects negative settings", func() {
+		_, err := NewProviderSimple("sdk-key", WithRedisConsumer(RedisConfig{Port: -1}))
+
+		Ω(err).Should(HaveOccurred())
+	})
+})
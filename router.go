@@ -0,0 +1,274 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// MetadataEnvironmentKey is the FlagMetadata key naming the environment a Router evaluated a flag in.
+const MetadataEnvironmentKey = "environment"
+
+// RouterConfig configures a Router.
+type RouterConfig struct {
+	// Providers maps environment names, such as "staging" and "production", to the provider of each Split
+	// environment.
+	Providers map[string]*SplitProvider
+	// Attribute is the context attribute naming the environment of an evaluation. It is not sent to Split.
+	Attribute string
+	// Default is the environment of evaluations whose context lacks Attribute. When empty, such evaluations fail
+	// with INVALID_CONTEXT.
+	Default string
+}
+
+// Router is an OpenFeature provider routing each evaluation to one of several SplitProviders, chosen by a context
+// attribute, so that a single process can serve tenants of several Split environments, possibly as the provider of
+// an OpenFeature domain. Each provider becomes ready on its own: evaluations routed to a provider whose Init failed
+// fail with PROVIDER_NOT_READY while the others are served. The state of the Router reflects every environment: it
+// emits READY only once all of them are ready, and otherwise ERROR or STALE, with the environment whose event caused
+// it in the EventMetadata.
+//
+// The hooks of the routed providers are not run, so register hooks such as NewEnrichmentHook on the client instead.
+type Router struct {
+	config RouterConfig
+	events chan openfeature.Event
+	done   chan struct{}
+	stop   sync.Once
+
+	mu     sync.RWMutex
+	failed map[string]error
+	states map[string]openfeature.EventType
+}
+
+var _ openfeature.FeatureProvider = &Router{}
+var _ openfeature.StateHandler = &Router{}
+var _ openfeature.EventHandler = &Router{}
+
+// NewRouter returns a Router over the providers of the config, which it owns from then on: it initializes and shuts
+// them down, and forwards their events.
+func NewRouter(config RouterConfig) (*Router, error) {
+	if len(config.Providers) == 0 {
+		return nil, errors.New("router needs at least one provider")
+	}
+	if config.Attribute == "" {
+		return nil, errors.New("router needs an environment attribute")
+	}
+	for environment, provider := range config.Providers {
+		if provider == nil {
+			return nil, fmt.Errorf("environment %q has no provider", environment)
+		}
+	}
+	if _, ok := config.Providers[config.Default]; config.Default != "" && !ok {
+		return nil, fmt.Errorf("default environment %q has no provider", config.Default)
+	}
+	router := &Router{
+		config: config,
+		events: make(chan openfeature.Event, eventBufferSize),
+		done:   make(chan struct{}),
+		failed: map[string]error{},
+		states: make(map[string]openfeature.EventType, len(config.Providers)),
+	}
+	for environment, provider := range config.Providers {
+		router.states[environment] = openfeature.ProviderReady
+		go router.forward(environment, provider.EventChannel())
+	}
+	return router, nil
+}
+
+func (router *Router) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{
+		Name: "Split",
+	}
+}
+
+func (router *Router) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	provider, environment, evalCtx, err := router.route(evalCtx)
+	if err != nil {
+		return openfeature.BoolResolutionDetail{Value: defaultValue, ProviderResolutionDetail: providerResolutionDetailError(*err, openfeature.ErrorReason, "")}
+	}
+	detail := provider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+	detail.FlagMetadata = withEnvironment(detail.FlagMetadata, environment)
+	return detail
+}
+
+func (router *Router) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	provider, environment, evalCtx, err := router.route(evalCtx)
+	if err != nil {
+		return openfeature.StringResolutionDetail{Value: defaultValue, ProviderResolutionDetail: providerResolutionDetailError(*err, openfeature.ErrorReason, "")}
+	}
+	detail := provider.StringEvaluation(ctx, flag, defaultValue, evalCtx)
+	detail.FlagMetadata = withEnvironment(detail.FlagMetadata, environment)
+	return detail
+}
+
+func (router *Router) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	provider, environment, evalCtx, err := router.route(evalCtx)
+	if err != nil {
+		return openfeature.FloatResolutionDetail{Value: defaultValue, ProviderResolutionDetail: providerResolutionDetailError(*err, openfeature.ErrorReason, "")}
+	}
+	detail := provider.FloatEvaluation(ctx, flag, defaultValue, evalCtx)
+	detail.FlagMetadata = withEnvironment(detail.FlagMetadata, environment)
+	return detail
+}
+
+func (router *Router) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	provider, environment, evalCtx, err := router.route(evalCtx)
+	if err != nil {
+		return openfeature.IntResolutionDetail{Value: defaultValue, ProviderResolutionDetail: providerResolutionDetailError(*err, openfeature.ErrorReason, "")}
+	}
+	detail := provider.IntEvaluation(ctx, flag, defaultValue, evalCtx)
+	detail.FlagMetadata = withEnvironment(detail.FlagMetadata, environment)
+	return detail
+}
+
+func (router *Router) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	provider, environment, evalCtx, err := router.route(evalCtx)
+	if err != nil {
+		return openfeature.InterfaceResolutionDetail{Value: defaultValue, ProviderResolutionDetail: providerResolutionDetailError(*err, openfeature.ErrorReason, "")}
+	}
+	detail := provider.ObjectEvaluation(ctx, flag, defaultValue, evalCtx)
+	detail.FlagMetadata = withEnvironment(detail.FlagMetadata, environment)
+	return detail
+}
+
+func (router *Router) Hooks() []openfeature.Hook {
+	return []openfeature.Hook{}
+}
+
+// Init initializes every provider, and fails when any of them fails, so that the OpenFeature state of the Router is
+// ERROR. The go-sdk still evaluates flags in that state, and evaluations routed to the providers that initialized are
+// served.
+func (router *Router) Init(evalCtx openfeature.EvaluationContext) error {
+	var errs []error
+	for environment, provider := range router.config.Providers {
+		err := provider.Init(evalCtx)
+		router.mu.Lock()
+		if err != nil {
+			router.failed[environment] = err
+			router.states[environment] = openfeature.ProviderError
+			errs = append(errs, fmt.Errorf("environment %q: %w", environment, err))
+		} else {
+			delete(router.failed, environment)
+			router.states[environment] = openfeature.ProviderReady
+		}
+		router.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown shuts down every provider and stops forwarding their events.
+func (router *Router) Shutdown() {
+	router.stop.Do(func() {
+		close(router.done)
+		for _, provider := range router.config.Providers {
+			provider.Shutdown()
+		}
+	})
+}
+
+func (router *Router) EventChannel() <-chan openfeature.Event {
+	return router.events
+}
+
+// Status reports the Status of the provider of each environment.
+func (router *Router) Status() map[string]Status {
+	statuses := make(map[string]Status, len(router.config.Providers))
+	for environment, provider := range router.config.Providers {
+		statuses[environment] = provider.Status()
+	}
+	return statuses
+}
+
+// forward emits the events of the provider of an environment as events of the router. Events changing the state of
+// the environment are emitted with the state of the router instead, so that one environment recovering does not
+// report the router as ready while another is not.
+func (router *Router) forward(environment string, events <-chan openfeature.Event) {
+	for {
+		select {
+		case <-router.done:
+			return
+		case event := <-events:
+			metadata := make(map[string]interface{}, len(event.EventMetadata)+1)
+			for key, value := range event.EventMetadata {
+				metadata[key] = value
+			}
+			metadata[MetadataEnvironmentKey] = environment
+			event.EventMetadata = metadata
+			if event.EventType != openfeature.ProviderConfigChange {
+				router.mu.Lock()
+				router.states[environment] = event.EventType
+				event.EventType = router.state()
+				router.mu.Unlock()
+			}
+			select {
+			case router.events <- event:
+			default:
+			}
+		}
+	}
+}
+
+// state returns ERROR when any environment is in error, otherwise STALE when any is stale, and READY when all are
+// ready. The caller holds the lock.
+func (router *Router) state() openfeature.EventType {
+	state := openfeature.ProviderReady
+	for _, environmentState := range router.states {
+		switch environmentState {
+		case openfeature.ProviderError:
+			return openfeature.ProviderError
+		case openfeature.ProviderStale:
+			state = openfeature.ProviderStale
+		}
+	}
+	return state
+}
+
+// route returns the provider of the environment named by the context, and the context without the environment
+// attribute.
+func (router *Router) route(evalCtx openfeature.FlattenedContext) (*SplitProvider, string, openfeature.FlattenedContext, *openfeature.ResolutionError) {
+	environment := router.config.Default
+	if value, ok := evalCtx[router.config.Attribute]; ok {
+		name, isString := value.(string)
+		if !isString {
+			err := openfeature.NewInvalidContextResolutionError(fmt.Sprintf("Context attribute %q naming the Split environment must be a string, got %T.", router.config.Attribute, value))
+			return nil, "", nil, &err
+		}
+		environment = name
+		routed := make(openfeature.FlattenedContext, len(evalCtx)-1)
+		for key, value := range evalCtx {
+			if key != router.config.Attribute {
+				routed[key] = value
+			}
+		}
+		evalCtx = routed
+	}
+	if environment == "" {
+		err := openfeature.NewInvalidContextResolutionError(fmt.Sprintf("Context attribute %q naming the Split environment is required and missing.", router.config.Attribute))
+		return nil, "", nil, &err
+	}
+	provider, ok := router.config.Providers[environment]
+	if !ok {
+		err := openfeature.NewInvalidContextResolutionError(fmt.Sprintf("Unknown Split environment %q.", environment))
+		return nil, "", nil, &err
+	}
+	router.mu.RLock()
+	initErr := router.failed[environment]
+	router.mu.RUnlock()
+	if initErr != nil {
+		err := openfeature.NewProviderNotReadyResolutionError(fmt.Sprintf("Split environment %q failed to initialize: %v", environment, initErr))
+		return nil, "", nil, &err
+	}
+	return provider, environment, evalCtx, nil
+}
+
+func withEnvironment(metadata openfeature.FlagMetadata, environment string) openfeature.FlagMetadata {
+	routed := make(openfeature.FlagMetadata, len(metadata)+1)
+	for key, value := range metadata {
+		routed[key] = value
+	}
+	routed[MetadataEnvironmentKey] = environment
+	return routed
+}
@@ -0,0 +1,185 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Router", func() {
+	var (
+		mockCtrl   *gomock.Controller
+		staging    *mocks.MockSplitClient
+		production *mocks.MockSplitClient
+		subject    *Router
+	)
+
+	newRouter := func(config RouterConfig) {
+		var err error
+		subject, err = NewRouter(config)
+		Ω(err).ShouldNot(HaveOccurred())
+		DeferCleanup(subject.Shutdown)
+	}
+
+	providers := func(stagingOpts ...Option) map[string]*SplitProvider {
+		stagingProvider, err := NewProvider(staging, stagingOpts...)
+		Ω(err).ShouldNot(HaveOccurred())
+		productionProvider, err := NewProvider(production)
+		Ω(err).ShouldNot(HaveOccurred())
+		return map[string]*SplitProvider{"staging": stagingProvider, "production": productionProvider}
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		staging = mocks.NewMockSplitClient(mockCtrl)
+		production = mocks.NewMockSplitClient(mockCtrl)
+	})
+
+	It("validates its config", func() {
+		_, err := NewRouter(RouterConfig{Attribute: "environment"})
+		Ω(err).Should(HaveOccurred())
+
+		_, err = NewRouter(RouterConfig{Providers: providers()})
+		Ω(err).Should(HaveOccurred())
+
+		_, err = NewRouter(RouterConfig{Providers: providers(), Attribute: "environment", Default: "qa"})
+		Ω(err).Should(MatchError(`default environment "qa" has no provider`))
+	})
+
+	It("routes evaluations by the environment attribute without sending it to Split", func() {
+		newRouter(RouterConfig{Providers: providers(), Attribute: "environment"})
+		staging.EXPECT().Treatment("user-1", "checkout", map[string]any{"plan": "pro"}).Return("on")
+		production.EXPECT().Treatment("user-1", "checkout", nil).Return("off")
+
+		stagingDetail := subject.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			"environment":            "staging",
+			"plan":                   "pro",
+		})
+		productionDetail := subject.BooleanEvaluation(context.Background(), "checkout", true, openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			"environment":            "production",
+		})
+
+		Ω(stagingDetail.Value).Should(BeTrue())
+		Ω(stagingDetail.FlagMetadata).Should(HaveKeyWithValue(MetadataEnvironmentKey, "staging"))
+		Ω(productionDetail.Value).Should(BeFalse())
+		Ω(productionDetail.FlagMetadata).Should(HaveKeyWithValue(MetadataEnvironmentKey, "production"))
+	})
+
+	It("routes evaluations without the attribute to the default environment", func() {
+		newRouter(RouterConfig{Providers: providers(), Attribute: "environment", Default: "production"})
+		production.EXPECT().Treatment("user-1", "theme", nil).Return("dark")
+
+		detail := subject.StringEvaluation(context.Background(), "theme", "light", openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+		})
+
+		Ω(detail.Value).Should(Equal("dark"))
+	})
+
+	It("fails evaluations it cannot route", func() {
+		newRouter(RouterConfig{Providers: providers(), Attribute: "environment"})
+
+		missing := subject.IntEvaluation(context.Background(), "limit", 5, openfeature.FlattenedContext{openfeature.TargetingKey: "user-1"})
+		unknown := subject.FloatEvaluation(context.Background(), "ratio", 0.5, openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			"environment":            "qa",
+		})
+
+		Ω(missing.Value).Should(Equal(int64(5)))
+		Ω(missing.ResolutionError.Error()).Should(ContainSubstring(string(openfeature.InvalidContextCode)))
+		Ω(unknown.Value).Should(Equal(0.5))
+		Ω(unknown.ResolutionError.Error()).Should(ContainSubstring(`Unknown Split environment "qa".`))
+	})
+
+	It("serves the environments that initialized when another one failed", func() {
+		factory := mocks.NewMockSplitFactory(mockCtrl)
+		factory.EXPECT().IsReady().Return(false)
+		factory.EXPECT().BlockUntilReady(gomock.Any()).Return(errors.New("timed out"))
+		newRouter(RouterConfig{
+			Providers: providers(
+				WithSplitFactory(factory),
+				WithRequiredFlags(ExpectedFlag{Name: "checkout", Type: openfeature.Boolean}),
+			),
+			Attribute: "environment",
+		})
+		production.EXPECT().Treatment("user-1", "checkout", nil).Return("on")
+
+		Ω(subject.Init(openfeature.EvaluationContext{})).Should(MatchError(ContainSubstring(`environment "staging"`)))
+
+		stagingDetail := subject.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			"environment":            "staging",
+		})
+		productionDetail := subject.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+			openfeature.TargetingKey: "user-1",
+			"environment":            "production",
+		})
+		Ω(stagingDetail.ResolutionError.Error()).Should(ContainSubstring(string(openfeature.ProviderNotReadyCode)))
+		Ω(productionDetail.Value).Should(BeTrue())
+	})
+
+	It("reports the router ready only once every environment is ready", func() {
+		breaker := WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Millisecond})
+		stagingProvider, err := NewProvider(staging, breaker)
+		Ω(err).ShouldNot(HaveOccurred())
+		productionProvider, err := NewProvider(production, breaker)
+		Ω(err).ShouldNot(HaveOccurred())
+		newRouter(RouterConfig{
+			Providers: map[string]*SplitProvider{"staging": stagingProvider, "production": productionProvider},
+			Attribute: "environment",
+		})
+		evaluate := func(environment string) {
+			subject.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+				openfeature.TargetingKey: "user-1",
+				"environment":            environment,
+			})
+		}
+		event := func(eventType openfeature.EventType, environment string) types.GomegaMatcher {
+			return And(
+				HaveField("EventType", eventType),
+				HaveField("ProviderEventDetails.EventMetadata", HaveKeyWithValue(MetadataEnvironmentKey, environment)),
+			)
+		}
+		staging.EXPECT().Treatment("user-1", "checkout", nil).Return("control")
+		production.EXPECT().Treatment("user-1", "checkout", nil).Return("control")
+
+		evaluate("staging")
+		Eventually(subject.EventChannel()).Should(Receive(event(openfeature.ProviderStale, "staging")))
+		evaluate("production")
+		Eventually(subject.EventChannel()).Should(Receive(event(openfeature.ProviderStale, "production")))
+
+		time.Sleep(2 * time.Millisecond)
+		staging.EXPECT().Treatment("user-1", "checkout", nil).Return("on")
+		production.EXPECT().Treatment("user-1", "checkout", nil).Return("on")
+
+		evaluate("staging")
+		Eventually(subject.EventChannel()).Should(Receive(event(openfeature.ProviderStale, "staging")))
+		evaluate("production")
+		Eventually(subject.EventChannel()).Should(Receive(event(openfeature.ProviderReady, "production")))
+	})
+
+	It("serves an OpenFeature domain", func() {
+		newRouter(RouterConfig{Providers: providers(), Attribute: "environment"})
+		staging.EXPECT().Treatment("user-1", "checkout", nil).Return("on")
+		domain := uuid.NewString()
+		Ω(openfeature.SetNamedProviderAndWait(domain, subject)).Should(Succeed())
+		client := openfeature.NewClient(domain)
+
+		value, err := client.BooleanValue(context.Background(), "checkout", false,
+			openfeature.NewEvaluationContext("user-1", map[string]any{"environment": "staging"}))
+
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(value).Should(BeTrue())
+	})
+})
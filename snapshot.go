@@ -0,0 +1,315 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/splitio/go-client/splitio/client"
+)
+
+// StaleReason is the OpenFeature STALE reason, reported for treatments served from a snapshot
+// while the Split SDK is still synchronizing. The go-sdk does not define a constant for it.
+const StaleReason openfeature.Reason = "STALE"
+
+// Snapshot is the last-known state of the split definitions, persisted so that a new provider
+// can serve treatments before the Split SDK is ready.
+type Snapshot struct {
+	SavedAt time.Time       `json:"savedAt"`
+	Splits  []SnapshotSplit `json:"splits"`
+}
+
+// SnapshotSplit is a split definition as reported by the Split manager, along with the treatments
+// this provider last served for it, by Split key.
+type SnapshotSplit struct {
+	client.SplitView
+	Assignments map[string]string `json:"assignments,omitempty"`
+}
+
+// maxSnapshotAssignments bounds the keys remembered per split, so that snapshots of flags evaluated for
+// many users stay small. Keys evaluated once the bound is reached are not remembered.
+const maxSnapshotAssignments = 10000
+
+// Treatment returns the treatment to serve from the snapshot for the Split key, if one is known. Split
+// managers do not expose targeting rules, default treatments or traffic allocation, so it is only known
+// for keys that were served a treatment before. Killed splits are never served, since Split serves them
+// their default treatment.
+func (split SnapshotSplit) Treatment(key string) (string, bool) {
+	if split.Killed {
+		return "", false
+	}
+	treatment, ok := split.Assignments[key]
+	return treatment, ok
+}
+
+// ReadSnapshot reads a snapshot previously written by SaveSnapshot.
+func ReadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("reading snapshot %s: %w", path, err)
+	}
+	return &snapshot, nil
+}
+
+// WithSnapshot restores the snapshot at path, if it exists, and serves treatments from it with reason STALE
+// until the Split factory given by WithSplitFactory is ready. The provider then emits PROVIDER_STALE on Init,
+// and PROVIDER_READY once the factory is ready. The snapshot is rewritten from the Split manager
+// given by WithSplitManager every interval, when interval is positive, and on Shutdown.
+func WithSnapshot(path string, interval time.Duration) Option {
+	return func(provider *SplitProvider) error {
+		snapshot := &snapshots{
+			path:     path,
+			interval: interval,
+			splits:   map[string]SnapshotSplit{},
+			observed: map[string]*observedAssignments{},
+			done:     make(chan struct{}),
+		}
+		restored, err := ReadSnapshot(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if restored != nil {
+			for _, split := range restored.Splits {
+				snapshot.splits[split.Name] = split
+			}
+		}
+		provider.snapshot = snapshot
+		return nil
+	}
+}
+
+type snapshots struct {
+	path      string
+	interval  time.Duration
+	mu        sync.RWMutex
+	splits    map[string]SnapshotSplit
+	observed  map[string]*observedAssignments
+	done      chan struct{}
+	stopOnce  sync.Once
+	watchOnce sync.Once
+}
+
+// observedAssignments are the treatments served for a split since the provider started, by Split key, while
+// the split had the given change number.
+type observedAssignments struct {
+	changeNumber int64
+	treatments   map[string]string
+}
+
+// snapshotReadyPollInterval is how often the provider checks whether the Split SDK is ready while serving a snapshot.
+const snapshotReadyPollInterval = 100 * time.Millisecond
+
+func (snapshot *snapshots) split(flag string) (SnapshotSplit, bool) {
+	snapshot.mu.RLock()
+	defer snapshot.mu.RUnlock()
+	split, ok := snapshot.splits[flag]
+	return split, ok
+}
+
+func (snapshot *snapshots) restored() bool {
+	snapshot.mu.RLock()
+	defer snapshot.mu.RUnlock()
+	return len(snapshot.splits) > 0
+}
+
+// observeSnapshot remembers the treatment served to the key, to be saved with the next snapshot. The change
+// number of the split is looked up when the first treatment is observed for it.
+func (provider *SplitProvider) observeSnapshot(flag string, key any, treatment string) {
+	snapshot := provider.snapshot
+	if snapshot == nil || provider.manager == nil {
+		return
+	}
+	snapshot.mu.RLock()
+	_, ok := snapshot.observed[flag]
+	snapshot.mu.RUnlock()
+	var changeNumber int64
+	if !ok {
+		view := provider.manager.Split(flag)
+		if view == nil {
+			return
+		}
+		changeNumber = view.ChangeNumber
+	}
+	snapshot.mu.Lock()
+	defer snapshot.mu.Unlock()
+	observed, ok := snapshot.observed[flag]
+	if !ok {
+		observed = &observedAssignments{changeNumber: changeNumber, treatments: map[string]string{}}
+		snapshot.observed[flag] = observed
+	}
+	assign(observed.treatments, fmt.Sprint(key), treatment)
+}
+
+// assign remembers the treatment of the key, unless the assignments are full.
+func assign(assignments map[string]string, key string, treatment string) {
+	if _, ok := assignments[key]; ok || len(assignments) < maxSnapshotAssignments {
+		assignments[key] = treatment
+	}
+}
+
+func (snapshot *snapshots) stop() {
+	snapshot.stopOnce.Do(func() {
+		close(snapshot.done)
+	})
+}
+
+// SaveSnapshot writes the split definitions currently known to the Split manager, along with the treatments
+// last served for each by key, to the snapshot file configured with WithSnapshot. Killed splits are saved
+// without assignments.
+func (provider *SplitProvider) SaveSnapshot() error {
+	snapshot := provider.snapshot
+	if snapshot == nil {
+		return errors.New("no snapshot configured")
+	}
+	if provider.manager == nil {
+		return ErrNoSplitManager
+	}
+	if !provider.isReady() {
+		// The manager would report an empty or partial set of splits and overwrite a good snapshot.
+		return errors.New("split SDK is not ready")
+	}
+	views := provider.manager.Splits()
+	saved := Snapshot{
+		SavedAt: time.Now().UTC(),
+		Splits:  make([]SnapshotSplit, 0, len(views)),
+	}
+	snapshot.mu.Lock()
+	splits := make(map[string]SnapshotSplit, len(views))
+	for _, view := range views {
+		split := SnapshotSplit{SplitView: view}
+		if !view.Killed {
+			split.Assignments = snapshot.assignments(view)
+		}
+		splits[view.Name] = split
+		saved.Splits = append(saved.Splits, split)
+	}
+	snapshot.splits = splits
+	snapshot.mu.Unlock()
+
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(snapshot.path, data)
+}
+
+// assignments merges the treatments served for the split since the provider started into those restored for
+// the same definition. Treatments observed under another change number are dropped, since they may no longer
+// be what Split would serve. The caller holds the lock.
+func (snapshot *snapshots) assignments(view client.SplitView) map[string]string {
+	assignments := map[string]string{}
+	if observed, ok := snapshot.observed[view.Name]; ok {
+		if observed.changeNumber == view.ChangeNumber {
+			for key, treatment := range observed.treatments {
+				assign(assignments, key, treatment)
+			}
+		} else {
+			delete(snapshot.observed, view.Name)
+		}
+	}
+	if previous, ok := snapshot.splits[view.Name]; ok && previous.ChangeNumber == view.ChangeNumber {
+		for key, treatment := range previous.Assignments {
+			if _, ok := assignments[key]; !ok {
+				assign(assignments, key, treatment)
+			}
+		}
+	}
+	if len(assignments) == 0 {
+		return nil
+	}
+	return assignments
+}
+
+func (provider *SplitProvider) startSnapshots() {
+	snapshot := provider.snapshot
+	if snapshot == nil || snapshot.interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(snapshot.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_ = provider.SaveSnapshot()
+			case <-snapshot.done:
+				return
+			}
+		}
+	}()
+}
+
+// watchSnapshot emits PROVIDER_STALE when the provider starts serving a restored snapshot, and PROVIDER_READY
+// once the Split SDK is ready.
+func (provider *SplitProvider) watchSnapshot() {
+	snapshot := provider.snapshot
+	if snapshot == nil || !snapshot.restored() {
+		return
+	}
+	snapshot.watchOnce.Do(func() {
+		if provider.isReady() {
+			return
+		}
+		provider.emit(openfeature.ProviderStale, "Serving treatments from a snapshot until the Split SDK is ready.")
+		go func() {
+			ticker := time.NewTicker(snapshotReadyPollInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					if provider.isReady() {
+						provider.emit(openfeature.ProviderReady, "Split SDK is ready.")
+						return
+					}
+				case <-snapshot.done:
+					return
+				}
+			}
+		}()
+	})
+}
+
+func (provider *SplitProvider) stopSnapshots() {
+	if provider.snapshot == nil {
+		return
+	}
+	provider.snapshot.stop()
+	_ = provider.SaveSnapshot()
+}
+
+func (provider *SplitProvider) servingSnapshot() bool {
+	return provider.snapshot != nil && !provider.isReady()
+}
+
+func (provider *SplitProvider) snapshotEvaluation(flag string, targetKey any) (evaluation, bool) {
+	if !provider.servingSnapshot() {
+		return evaluation{}, false
+	}
+	split, ok := provider.snapshot.split(flag)
+	if !ok {
+		return evaluation{}, false
+	}
+	treatment, ok := split.Treatment(fmt.Sprint(targetKey))
+	if !ok {
+		return evaluation{}, false
+	}
+	metadata := openfeature.FlagMetadata{
+		MetadataSourceKey: SourceSnapshot,
+	}
+	if config, ok := split.Configs[treatment]; ok {
+		metadata[MetadataConfigKey] = config
+	}
+	return evaluation{
+		treatment: treatment,
+		reason:    StaleReason,
+		metadata:  metadata,
+	}, true
+}
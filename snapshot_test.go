@@ -0,0 +1,352 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"github.com/splitio/go-client/splitio/client"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Snapshots", func() {
+	var (
+		mockSplitClient  *mocks.MockSplitClient
+		mockSplitFactory *mocks.MockSplitFactory
+		mockSplitManager *mocks.MockSplitManager
+		path             string
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		mockSplitFactory = mocks.NewMockSplitFactory(mockCtrl)
+		mockSplitManager = mocks.NewMockSplitManager(mockCtrl)
+		path = filepath.Join(GinkgoT().TempDir(), "snapshot.json")
+	})
+
+	writeSnapshot := func(splits ...SnapshotSplit) {
+		data, err := json.Marshal(Snapshot{SavedAt: time.Now(), Splits: splits})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(os.WriteFile(path, data, 0o600)).Should(Succeed())
+	}
+
+	newSubject := func() *SplitProvider {
+		subject, err := NewProvider(mockSplitClient,
+			WithSplitFactory(mockSplitFactory),
+			WithSplitManager(mockSplitManager),
+			WithSnapshot(path, 0))
+		Ω(err).ShouldNot(HaveOccurred())
+		return subject
+	}
+
+	Describe("serving", func() {
+		BeforeEach(func() {
+			writeSnapshot(
+				SnapshotSplit{
+					SplitView: client.SplitView{
+						Name:       "checkout",
+						Treatments: []string{"on", "off"},
+						Configs:    map[string]string{"on": `{"color":"red"}`},
+					},
+					Assignments: map[string]string{"user-1": "on"},
+				},
+				SnapshotSplit{
+					SplitView: client.SplitView{
+						Name:       "banner",
+						Treatments: []string{"off", "off"},
+					},
+				},
+				SnapshotSplit{
+					SplitView: client.SplitView{
+						Name:       "killed",
+						Killed:     true,
+						Treatments: []string{"on", "on"},
+					},
+				},
+				SnapshotSplit{
+					SplitView: client.SplitView{
+						Name:       "rollout",
+						Treatments: []string{"on", "off"},
+					},
+				},
+			)
+			mockSplitManager.EXPECT().Split(gomock.Any()).Return(nil).AnyTimes()
+		})
+
+		It("serves the last-known treatment of the key with reason STALE while the SDK is not ready", func() {
+			mockSplitFactory.EXPECT().IsReady().Return(false).AnyTimes()
+			subject := newSubject()
+
+			// act
+			result := subject.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{
+				openfeature.TargetingKey: "user-1",
+			})
+
+			Ω(result).Should(Equal(openfeature.BoolResolutionDetail{
+				Value: true,
+				ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+					Reason:  StaleReason,
+					Variant: "on",
+					FlagMetadata: openfeature.FlagMetadata{
+						MetadataSourceKey: SourceSnapshot,
+						MetadataConfigKey: `{"color":"red"}`,
+					},
+				},
+			}))
+		})
+
+		It("asks the client for a key without a last-known treatment, even when the split reports a single treatment", func() {
+			key := uuid.NewString()
+			mockSplitFactory.EXPECT().IsReady().Return(false).AnyTimes()
+			mockSplitClient.EXPECT().Treatment(key, "banner", nil).Return("control")
+			subject := newSubject()
+
+			// act
+			result := subject.StringEvaluation(context.Background(), "banner", "default", openfeature.FlattenedContext{
+				openfeature.TargetingKey: key,
+			})
+
+			Ω(result.Value).Should(Equal("default"))
+			Ω(result.ResolutionError).Should(Equal(openfeature.NewFlagNotFoundResolutionError("Flag not found.")))
+		})
+
+		It("asks the client when the snapshot cannot tell the treatment", func() {
+			key := uuid.NewString()
+			mockSplitFactory.EXPECT().IsReady().Return(false).AnyTimes()
+			mockSplitClient.EXPECT().Treatment(key, "rollout", nil).Return("control")
+			subject := newSubject()
+
+			// act
+			result := subject.StringEvaluation(context.Background(), "rollout", "default", openfeature.FlattenedContext{
+				openfeature.TargetingKey: key,
+			})
+
+			Ω(result.Value).Should(Equal("default"))
+			Ω(result.ResolutionError).Should(Equal(openfeature.NewFlagNotFoundResolutionError("Flag not found.")))
+		})
+
+		It("does not serve the treatment last served to another key", func() {
+			key := uuid.NewString()
+			mockSplitFactory.EXPECT().IsReady().Return(false).AnyTimes()
+			mockSplitClient.EXPECT().Treatment(key, "checkout", nil).Return("off")
+			subject := newSubject()
+
+			// act
+			result := subject.BooleanEvaluation(context.Background(), "checkout", true, openfeature.FlattenedContext{
+				openfeature.TargetingKey: key,
+			})
+
+			Ω(result.Value).Should(BeFalse())
+			Ω(result.Reason).Should(Equal(openfeature.TargetingMatchReason))
+		})
+
+		It("asks the client for killed splits", func() {
+			key := uuid.NewString()
+			mockSplitFactory.EXPECT().IsReady().Return(false).AnyTimes()
+			mockSplitClient.EXPECT().Treatment(key, "killed", nil).Return("off")
+			subject := newSubject()
+
+			// act
+			result := subject.StringEvaluation(context.Background(), "killed", "", openfeature.FlattenedContext{
+				openfeature.TargetingKey: key,
+			})
+
+			Ω(result.Value).Should(Equal("off"))
+			Ω(result.Reason).Should(Equal(openfeature.TargetingMatchReason))
+		})
+
+		It("evaluates live once the SDK is ready", func() {
+			key := uuid.NewString()
+			mockSplitFactory.EXPECT().IsReady().Return(true).AnyTimes()
+			mockSplitClient.EXPECT().Treatment(key, "checkout", nil).Return("off")
+			subject := newSubject()
+
+			// act
+			result := subject.BooleanEvaluation(context.Background(), "checkout", true, openfeature.FlattenedContext{
+				openfeature.TargetingKey: key,
+			})
+
+			Ω(result.Value).Should(BeFalse())
+			Ω(result.Reason).Should(Equal(openfeature.TargetingMatchReason))
+		})
+	})
+
+	Describe("SaveSnapshot", func() {
+		It("writes the manager's splits with the treatments last served by key", func() {
+			key := uuid.NewString()
+			mockSplitFactory.EXPECT().IsReady().Return(true).AnyTimes()
+			mockSplitClient.EXPECT().Treatment(key, "checkout", nil).Return("off")
+			mockSplitManager.EXPECT().Splits().Return([]client.SplitView{
+				{Name: "checkout", Treatments: []string{"on", "off"}, ChangeNumber: 3},
+				{Name: "banner", Treatments: []string{"blue"}, ChangeNumber: 5},
+				{Name: "killed", Killed: true, Treatments: []string{"on", "off"}, ChangeNumber: 7},
+			})
+			mockSplitClient.EXPECT().Treatment(key, "killed", nil).Return("off")
+			mockSplitManager.EXPECT().Split("checkout").Return(&client.SplitView{Name: "checkout", ChangeNumber: 3})
+			mockSplitManager.EXPECT().Split("killed").Return(&client.SplitView{Name: "killed", ChangeNumber: 7})
+			subject := newSubject()
+			subject.BooleanEvaluation(context.Background(), "checkout", true, openfeature.FlattenedContext{
+				openfeature.TargetingKey: key,
+			})
+			subject.BooleanEvaluation(context.Background(), "killed", true, openfeature.FlattenedContext{
+				openfeature.TargetingKey: key,
+			})
+
+			// act
+			err := subject.SaveSnapshot()
+
+			Ω(err).ShouldNot(HaveOccurred())
+			saved, err := ReadSnapshot(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(saved.Splits).Should(ConsistOf(
+				SnapshotSplit{
+					SplitView:   client.SplitView{Name: "checkout", Treatments: []string{"on", "off"}, ChangeNumber: 3},
+					Assignments: map[string]string{key: "off"},
+				},
+				SnapshotSplit{
+					SplitView: client.SplitView{Name: "banner", Treatments: []string{"blue"}, ChangeNumber: 5},
+				},
+				SnapshotSplit{
+					SplitView: client.SplitView{Name: "killed", Killed: true, Treatments: []string{"on", "off"}, ChangeNumber: 7},
+				},
+			))
+		})
+
+		It("drops the treatments served before the split changed", func() {
+			key := uuid.NewString()
+			writeSnapshot(SnapshotSplit{
+				SplitView:   client.SplitView{Name: "checkout", Treatments: []string{"on", "off"}, ChangeNumber: 3},
+				Assignments: map[string]string{"user-1": "on"},
+			})
+			mockSplitFactory.EXPECT().IsReady().Return(true).AnyTimes()
+			mockSplitClient.EXPECT().Treatment(key, "checkout", nil).Return("off")
+			mockSplitManager.EXPECT().Split("checkout").Return(&client.SplitView{Name: "checkout", ChangeNumber: 3})
+			mockSplitManager.EXPECT().Splits().Return([]client.SplitView{
+				{Name: "checkout", Treatments: []string{"on", "off"}, ChangeNumber: 4},
+			})
+			subject := newSubject()
+			subject.BooleanEvaluation(context.Background(), "checkout", true, openfeature.FlattenedContext{
+				openfeature.TargetingKey: key,
+			})
+
+			// act
+			err := subject.SaveSnapshot()
+
+			Ω(err).ShouldNot(HaveOccurred())
+			saved, err := ReadSnapshot(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(saved.Splits).Should(ConsistOf(SnapshotSplit{
+				SplitView: client.SplitView{Name: "checkout", Treatments: []string{"on", "off"}, ChangeNumber: 4},
+			}))
+		})
+
+		It("refuses to overwrite the snapshot while the SDK is not ready", func() {
+			writeSnapshot(SnapshotSplit{SplitView: client.SplitView{Name: "checkout"}, Assignments: map[string]string{"user-1": "on"}})
+			mockSplitFactory.EXPECT().IsReady().Return(false).AnyTimes()
+			subject := newSubject()
+
+			// act
+			err := subject.SaveSnapshot()
+
+			Ω(err).Should(HaveOccurred())
+			saved, err := ReadSnapshot(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(saved.Splits).Should(HaveLen(1))
+		})
+
+		It("saves on shutdown", func() {
+			mockSplitFactory.EXPECT().IsReady().Return(true).AnyTimes()
+			mockSplitManager.EXPECT().Splits().Return([]client.SplitView{{Name: "checkout"}})
+			subject := newSubject()
+
+			// act
+			subject.Shutdown()
+
+			Ω(path).Should(BeARegularFile())
+		})
+
+		It("saves periodically", func() {
+			mockSplitFactory.EXPECT().IsReady().Return(true).AnyTimes()
+			mockSplitManager.EXPECT().Splits().Return([]client.SplitView{{Name: "checkout"}}).MinTimes(1)
+			subject, err := NewProvider(mockSplitClient,
+				WithSplitFactory(mockSplitFactory),
+				WithSplitManager(mockSplitManager),
+				WithSnapshot(path, 10*time.Millisecond))
+			Ω(err).ShouldNot(HaveOccurred())
+			DeferCleanup(subject.Shutdown)
+
+			Eventually(path).Should(BeARegularFile())
+		})
+
+		It("fails without a snapshot configured", func() {
+			subject, err := NewProvider(mockSplitClient)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(subject.SaveSnapshot()).ShouldNot(Succeed())
+		})
+	})
+
+	Describe("events", func() {
+		It("emits PROVIDER_STALE while serving a restored snapshot and PROVIDER_READY once the SDK is ready", func() {
+			writeSnapshot(SnapshotSplit{SplitView: client.SplitView{Name: "checkout"}, Assignments: map[string]string{"user-1": "on"}})
+			var ready atomic.Bool
+			mockSplitFactory.EXPECT().IsReady().DoAndReturn(ready.Load).AnyTimes()
+			mockSplitManager.EXPECT().Splits().Return(nil).AnyTimes()
+			subject := newSubject()
+			DeferCleanup(subject.Shutdown)
+
+			// act
+			err := subject.Init(openfeature.EvaluationContext{})
+
+			Ω(err).ShouldNot(HaveOccurred())
+			Eventually(subject.EventChannel()).Should(Receive(HaveField("EventType", openfeature.ProviderStale)))
+			Consistently(subject.EventChannel(), 300*time.Millisecond).ShouldNot(Receive())
+			ready.Store(true)
+			Eventually(subject.EventChannel()).Should(Receive(HaveField("EventType", openfeature.ProviderReady)))
+		})
+
+		It("emits nothing when the SDK is ready on Init", func() {
+			writeSnapshot(SnapshotSplit{SplitView: client.SplitView{Name: "checkout"}, Assignments: map[string]string{"user-1": "on"}})
+			mockSplitFactory.EXPECT().IsReady().Return(true).AnyTimes()
+			mockSplitManager.EXPECT().Splits().Return(nil).AnyTimes()
+			subject := newSubject()
+			DeferCleanup(subject.Shutdown)
+
+			// act
+			err := subject.Init(openfeature.EvaluationContext{})
+
+			Ω(err).ShouldNot(HaveOccurred())
+			Consistently(subject.EventChannel()).ShouldNot(Receive())
+		})
+
+		It("emits nothing without a restored snapshot", func() {
+			mockSplitFactory.EXPECT().IsReady().Return(false).AnyTimes()
+			subject := newSubject()
+
+			// act
+			err := subject.Init(openfeature.EvaluationContext{})
+
+			Ω(err).ShouldNot(HaveOccurred())
+			Consistently(subject.EventChannel()).ShouldNot(Receive())
+		})
+	})
+
+	It("fails to create the provider with a corrupt snapshot", func() {
+		Ω(os.WriteFile(path, []byte("{"), 0o600)).Should(Succeed())
+
+		// act
+		_, err := NewProvider(mockSplitClient, WithSnapshot(path, 0))
+
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -1,7 +1,34 @@
 package fork_split_openfeature_provider_go
 
-//go:generate go run go.uber.org/mock/mockgen -package mocks -source=splitClient.go -destination=mocks/mockSplitClient.go
+import "github.com/splitio/go-client/splitio/client"
+
+//go:generate go run go.uber.org/mock/mockgen -package mocks -source=splitClient.go -destination=mocks/mockSplitClient.go -mock_names=ISplitClient=MockSplitClient,ISplitClientWithConfig=MockSplitClientWithConfig,ISplitTracker=MockSplitTracker,ISplitFactory=MockSplitFactory,ISplitManager=MockSplitManager
 
 type ISplitClient interface {
 	Treatment(key any, feature string, attributes map[string]any) string
 }
+
+// ISplitClientWithConfig is implemented by Split clients that also return the dynamic configuration attached
+// to a treatment, such as the Split SDK client. The provider then reports it in FlagMetadata.
+type ISplitClientWithConfig interface {
+	TreatmentWithConfig(key any, feature string, attributes map[string]any) client.TreatmentResult
+}
+
+// ISplitTracker is implemented by Split clients that can track events, such as the Split SDK client. The provider
+// then implements the OpenFeature Tracker interface with it.
+type ISplitTracker interface {
+	Track(key string, trafficType string, eventType string, value interface{}, properties map[string]interface{}) error
+}
+
+// ISplitFactory reports the readiness of the Split SDK backing the client.
+type ISplitFactory interface {
+	IsReady() bool
+	BlockUntilReady(timer int) error
+}
+
+// ISplitManager exposes the split definitions currently known to the Split SDK.
+type ISplitManager interface {
+	SplitNames() []string
+	Splits() []client.SplitView
+	Split(feature string) *client.SplitView
+}
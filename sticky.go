@@ -0,0 +1,239 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// StickyAssignment is a treatment pinned by the sticky-assignment layer, with the config Split returned for it.
+type StickyAssignment struct {
+	Treatment string  `json:"treatment"`
+	Config    *string `json:"config,omitempty"`
+}
+
+// StickyStore persists the treatments pinned by the sticky-assignment layer, keyed by flag and targeting key.
+// Implementations must be safe for concurrent use.
+type StickyStore interface {
+	// Get returns the assignment pinned for the flag and key, if one exists and has not expired.
+	Get(flag string, key string) (StickyAssignment, bool)
+	// Set pins the assignment for the flag and key. A ttl of zero or less pins it indefinitely.
+	Set(flag string, key string, assignment StickyAssignment, ttl time.Duration) error
+}
+
+// WithStickyAssignments pins the first treatment served for each flag and targeting key in the given store
+// for ttl, so later evaluations return the same variant and config with reason CACHED even if the split changes.
+func WithStickyAssignments(store StickyStore, ttl time.Duration) Option {
+	return func(provider *SplitProvider) error {
+		if store == nil {
+			return errors.New("sticky store must not be nil")
+		}
+		provider.sticky = &stickyAssignments{
+			store: store,
+			ttl:   ttl,
+		}
+		return nil
+	}
+}
+
+type stickyAssignments struct {
+	store StickyStore
+	ttl   time.Duration
+}
+
+func (sticky *stickyAssignments) lookup(flag string, key any) (StickyAssignment, bool) {
+	if sticky == nil {
+		return StickyAssignment{}, false
+	}
+	return sticky.store.Get(flag, fmt.Sprint(key))
+}
+
+func (sticky *stickyAssignments) pin(flag string, key any, treatment string, config *string) {
+	if sticky == nil {
+		return
+	}
+	// Pinning is best effort: a store failure should not change the treatment being served.
+	_ = sticky.store.Set(flag, fmt.Sprint(key), StickyAssignment{Treatment: treatment, Config: config}, sticky.ttl)
+}
+
+type stickyEntry struct {
+	StickyAssignment
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+func newStickyEntry(assignment StickyAssignment, ttl time.Duration, now time.Time) stickyEntry {
+	entry := stickyEntry{StickyAssignment: assignment}
+	if ttl > 0 {
+		entry.ExpiresAt = now.Add(ttl)
+	}
+	return entry
+}
+
+func (entry stickyEntry) expired(now time.Time) bool {
+	return !entry.ExpiresAt.IsZero() && !now.Before(entry.ExpiresAt)
+}
+
+// minStickySweep is the number of entries an InMemoryStickyStore holds before it first sweeps expired ones.
+const minStickySweep = 1024
+
+// InMemoryStickyStore is a StickyStore that keeps pinned treatments in process memory. Expired entries are swept
+// whenever the number of entries doubles since the last sweep, so memory follows the number of live assignments.
+type InMemoryStickyStore struct {
+	mu      sync.Mutex
+	entries map[string]map[string]stickyEntry
+	size    int
+	sweepAt int
+	now     func() time.Time
+}
+
+var _ StickyStore = &InMemoryStickyStore{}
+
+func NewInMemoryStickyStore() *InMemoryStickyStore {
+	return &InMemoryStickyStore{
+		entries: map[string]map[string]stickyEntry{},
+		sweepAt: minStickySweep,
+		now:     time.Now,
+	}
+}
+
+func (store *InMemoryStickyStore) Get(flag string, key string) (StickyAssignment, bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	entry, ok := store.entries[flag][key]
+	if !ok {
+		return StickyAssignment{}, false
+	}
+	if entry.expired(store.now()) {
+		delete(store.entries[flag], key)
+		store.size--
+		return StickyAssignment{}, false
+	}
+	return entry.StickyAssignment, true
+}
+
+func (store *InMemoryStickyStore) Set(flag string, key string, assignment StickyAssignment, ttl time.Duration) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	now := store.now()
+	if store.entries[flag] == nil {
+		store.entries[flag] = map[string]stickyEntry{}
+	}
+	if _, ok := store.entries[flag][key]; !ok {
+		store.size++
+	}
+	store.entries[flag][key] = newStickyEntry(assignment, ttl, now)
+	if store.size >= store.sweepAt {
+		store.sweep(now)
+		store.sweepAt = max(2*store.size, minStickySweep)
+	}
+	return nil
+}
+
+// Len returns the number of pinned treatments, including expired ones that have not been swept yet.
+func (store *InMemoryStickyStore) Len() int {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.size
+}
+
+// sweep deletes expired entries. The caller holds the lock.
+func (store *InMemoryStickyStore) sweep(now time.Time) {
+	for flag, keys := range store.entries {
+		for key, entry := range keys {
+			if entry.expired(now) {
+				delete(keys, key)
+				store.size--
+			}
+		}
+		if len(keys) == 0 {
+			delete(store.entries, flag)
+		}
+	}
+}
+
+// FileStickyStore is a StickyStore that keeps pinned treatments in a JSON file so that they survive restarts.
+// The whole file is rewritten on every Set, so it is intended for modest numbers of keys.
+type FileStickyStore struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]map[string]stickyEntry
+	now     func() time.Time
+}
+
+var _ StickyStore = &FileStickyStore{}
+
+// NewFileStickyStore returns a FileStickyStore backed by the file at path, loading any assignments already in it.
+func NewFileStickyStore(path string) (*FileStickyStore, error) {
+	store := &FileStickyStore{
+		path:    path,
+		entries: map[string]map[string]stickyEntry{},
+		now:     time.Now,
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &store.entries); err != nil {
+			return nil, fmt.Errorf("reading sticky store %s: %w", path, err)
+		}
+	}
+	return store, nil
+}
+
+func (store *FileStickyStore) Get(flag string, key string) (StickyAssignment, bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	entry, ok := store.entries[flag][key]
+	if !ok || entry.expired(store.now()) {
+		return StickyAssignment{}, false
+	}
+	return entry.StickyAssignment, true
+}
+
+func (store *FileStickyStore) Set(flag string, key string, assignment StickyAssignment, ttl time.Duration) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	now := store.now()
+	if store.entries[flag] == nil {
+		store.entries[flag] = map[string]stickyEntry{}
+	}
+	store.entries[flag][key] = newStickyEntry(assignment, ttl, now)
+	for _, keys := range store.entries {
+		for k, entry := range keys {
+			if entry.expired(now) {
+				delete(keys, k)
+			}
+		}
+	}
+	data, err := json.Marshal(store.entries)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(store.path, data)
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it into place,
+// so readers never observe a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,211 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"github.com/splitio/go-client/splitio/client"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Sticky assignments", func() {
+	var (
+		mockSplitClient *mocks.MockSplitClient
+		store           *InMemoryStickyStore
+		subject         *SplitProvider
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		store = NewInMemoryStickyStore()
+		var err error
+		subject, err = NewProvider(mockSplitClient, WithStickyAssignments(store, time.Hour))
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("rejects a nil store", func() {
+		_, err := NewProvider(mockSplitClient, WithStickyAssignments(nil, time.Hour))
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("serves the first treatment with reason CACHED on later evaluations", func() {
+		key := uuid.NewString()
+		feature := uuid.NewString()
+		evalCtx := openfeature.FlattenedContext{
+			openfeature.TargetingKey: key,
+		}
+		mockSplitClient.EXPECT().
+			Treatment(key, feature, nil).
+			Return("on").
+			Times(1)
+
+		// act
+		first := subject.BooleanEvaluation(context.Background(), feature, false, evalCtx)
+		second := subject.BooleanEvaluation(context.Background(), feature, false, evalCtx)
+
+		Ω(first.Value).Should(BeTrue())
+		Ω(first.Reason).Should(Equal(openfeature.TargetingMatchReason))
+		Ω(second).Should(Equal(openfeature.BoolResolutionDetail{
+			Value: true,
+			ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+				Reason:  openfeature.CachedReason,
+				Variant: "on",
+			},
+		}))
+	})
+
+	It("serves the config of the pinned treatment", func() {
+		mockConfigClient := mocks.NewMockSplitClientWithConfig(gomock.NewController(GinkgoT()))
+		subject, err := NewProvider(struct {
+			*mocks.MockSplitClient
+			*mocks.MockSplitClientWithConfig
+		}{mockSplitClient, mockConfigClient}, WithStickyAssignments(store, time.Hour))
+		Ω(err).ShouldNot(HaveOccurred())
+		key := uuid.NewString()
+		feature := uuid.NewString()
+		config := `{"color":"red"}`
+		mockConfigClient.EXPECT().
+			TreatmentWithConfig(key, feature, nil).
+			Return(client.TreatmentResult{Treatment: "on", Config: &config}).
+			Times(1)
+
+		// act
+		subject.BooleanEvaluation(context.Background(), feature, false, openfeature.FlattenedContext{openfeature.TargetingKey: key})
+		second := subject.BooleanEvaluation(context.Background(), feature, false, openfeature.FlattenedContext{openfeature.TargetingKey: key})
+
+		Ω(second.Reason).Should(Equal(openfeature.CachedReason))
+		Ω(second.FlagMetadata).Should(Equal(openfeature.FlagMetadata{MetadataConfigKey: config}))
+	})
+
+	It("pins treatments per targeting key", func() {
+		feature := uuid.NewString()
+		firstKey := uuid.NewString()
+		secondKey := uuid.NewString()
+		mockSplitClient.EXPECT().Treatment(firstKey, feature, nil).Return("a")
+		mockSplitClient.EXPECT().Treatment(secondKey, feature, nil).Return("b")
+
+		// act
+		first := subject.StringEvaluation(context.Background(), feature, "", openfeature.FlattenedContext{
+			openfeature.TargetingKey: firstKey,
+		})
+		second := subject.StringEvaluation(context.Background(), feature, "", openfeature.FlattenedContext{
+			openfeature.TargetingKey: secondKey,
+		})
+
+		Ω(first.Value).Should(Equal("a"))
+		Ω(second.Value).Should(Equal("b"))
+		Ω(pinned(store, feature, firstKey)).Should(Equal("a"))
+		Ω(pinned(store, feature, secondKey)).Should(Equal("b"))
+	})
+
+	It("does not pin control treatments", func() {
+		key := uuid.NewString()
+		feature := uuid.NewString()
+		evalCtx := openfeature.FlattenedContext{
+			openfeature.TargetingKey: key,
+		}
+		mockSplitClient.EXPECT().
+			Treatment(key, feature, nil).
+			Return("control").
+			Times(2)
+
+		// act
+		subject.StringEvaluation(context.Background(), feature, "", evalCtx)
+		result := subject.StringEvaluation(context.Background(), feature, "", evalCtx)
+
+		Ω(result.Reason).Should(Equal(openfeature.DefaultReason))
+		_, ok := store.Get(feature, key)
+		Ω(ok).Should(BeFalse())
+	})
+
+	Describe("InMemoryStickyStore", func() {
+		It("expires entries after the ttl", func() {
+			feature := uuid.NewString()
+			key := uuid.NewString()
+
+			// act
+			Ω(store.Set(feature, key, StickyAssignment{Treatment: "on"}, 10*time.Millisecond)).Should(Succeed())
+
+			Ω(pinned(store, feature, key)).Should(Equal("on"))
+			Eventually(func() bool {
+				_, ok := store.Get(feature, key)
+				return ok
+			}).Should(BeFalse())
+		})
+
+		It("sweeps expired entries as it grows", func() {
+			feature := uuid.NewString()
+
+			// act
+			for i := 0; i < 5000; i++ {
+				Ω(store.Set(feature, uuid.NewString(), StickyAssignment{Treatment: "on"}, time.Nanosecond)).Should(Succeed())
+			}
+
+			Ω(store.Len()).Should(BeNumerically("<", 2048))
+		})
+	})
+
+	Describe("FileStickyStore", func() {
+		var path string
+
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "sticky.json")
+		})
+
+		It("persists assignments across instances", func() {
+			feature := uuid.NewString()
+			key := uuid.NewString()
+			config := `{"color":"red"}`
+			fileStore, err := NewFileStickyStore(path)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// act
+			Ω(fileStore.Set(feature, key, StickyAssignment{Treatment: "off", Config: &config}, time.Hour)).Should(Succeed())
+
+			reloaded, err := NewFileStickyStore(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			assignment, ok := reloaded.Get(feature, key)
+			Ω(ok).Should(BeTrue())
+			Ω(assignment).Should(Equal(StickyAssignment{Treatment: "off", Config: &config}))
+		})
+
+		It("does not return expired assignments", func() {
+			feature := uuid.NewString()
+			key := uuid.NewString()
+			fileStore, err := NewFileStickyStore(path)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// act
+			Ω(fileStore.Set(feature, key, StickyAssignment{Treatment: "off"}, 10*time.Millisecond)).Should(Succeed())
+
+			Eventually(func() bool {
+				_, ok := fileStore.Get(feature, key)
+				return ok
+			}).Should(BeFalse())
+		})
+
+		It("fails on a corrupt file", func() {
+			Ω(os.WriteFile(path, []byte("not json"), 0o600)).Should(Succeed())
+
+			// act
+			_, err := NewFileStickyStore(path)
+
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
+
+func pinned(store StickyStore, flag string, key string) string {
+	assignment, ok := store.Get(flag, key)
+	Ω(ok).Should(BeTrue())
+	return assignment.Treatment
+}
@@ -0,0 +1,84 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"context"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// TrafficTypeKey is the context attribute naming the Split traffic type of tracked events, unless WithTrafficTypes
+// names another one. Events of contexts without it are tracked with DefaultTrafficType.
+const TrafficTypeKey = "trafficType"
+
+// DefaultTrafficType is the traffic type of tracked events whose context does not name one.
+const DefaultTrafficType = "user"
+
+var _ openfeature.Tracker = &SplitProvider{}
+
+// Track sends the event to Split when the Split client can track events, as the Split SDK client can. The event is
+// tracked for the key of its traffic type, read as for evaluations, with the value and attributes of the details as
+// its value and properties. The properties go through the attribute filter like evaluation attributes. Events
+// without a key are dropped, since OpenFeature gives Track no way to report an error.
+func (provider *SplitProvider) Track(_ context.Context, trackingEventName string, evalCtx openfeature.EvaluationContext, details openfeature.TrackingEventDetails) {
+	if provider.tracker == nil {
+		return
+	}
+	flatCtx := flattenContext(evalCtx)
+	trafficType, ok := flatCtx[provider.trafficTypes.attribute()].(string)
+	if !ok || trafficType == "" {
+		trafficType = DefaultTrafficType
+	}
+	key, ok := provider.trackKey(trafficType, flatCtx)
+	if !ok {
+		return
+	}
+	_ = provider.tracker.Track(key, trafficType, trackingEventName, details.Value(), provider.trackProperties(details))
+}
+
+// trackKey returns the Split key of an event of the traffic type, if the context holds one. Unlike evaluations,
+// events are never tracked for an anonymous key.
+func (provider *SplitProvider) trackKey(trafficType string, flatCtx openfeature.FlattenedContext) (string, bool) {
+	var attributes []string
+	if attribute, ok := provider.trafficTypes.key(trafficType); ok {
+		attributes = []string{attribute}
+	} else {
+		attributes = append([]string{openfeature.TargetingKey}, provider.keyFallback...)
+	}
+	for _, attribute := range attributes {
+		key, ok, resolutionErr := contextKey(flatCtx, attribute)
+		if resolutionErr != nil {
+			return "", false
+		}
+		if ok {
+			return provider.attributeFilter.key(attribute, key).(string), true
+		}
+	}
+	return "", false
+}
+
+// trackProperties returns the attributes of the details to send to Split as event properties, or nil if there are
+// none.
+func (provider *SplitProvider) trackProperties(details openfeature.TrackingEventDetails) map[string]interface{} {
+	properties := map[string]interface{}{}
+	for name, value := range details.Attributes() {
+		if value, ok := provider.attributeFilter.apply(name, value); ok {
+			properties[name] = value
+		}
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return properties
+}
+
+func flattenContext(evalCtx openfeature.EvaluationContext) openfeature.FlattenedContext {
+	attributes := evalCtx.Attributes()
+	flatCtx := make(openfeature.FlattenedContext, len(attributes)+1)
+	for name, value := range attributes {
+		flatCtx[name] = value
+	}
+	if targetingKey := evalCtx.TargetingKey(); targetingKey != "" {
+		flatCtx[openfeature.TargetingKey] = targetingKey
+	}
+	return flatCtx
+}
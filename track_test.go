@@ -0,0 +1,99 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Track", func() {
+	var (
+		mockSplitClient  *mocks.MockSplitClient
+		mockSplitTracker *mocks.MockSplitTracker
+		splitClient      ISplitClient
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		mockSplitTracker = mocks.NewMockSplitTracker(mockCtrl)
+		splitClient = struct {
+			*mocks.MockSplitClient
+			*mocks.MockSplitTracker
+		}{mockSplitClient, mockSplitTracker}
+	})
+
+	newSubject := func(opts ...Option) *SplitProvider {
+		subject, err := NewProvider(splitClient, opts...)
+		Ω(err).ShouldNot(HaveOccurred())
+		return subject
+	}
+
+	It("tracks the event for the targeting key with the default traffic type", func() {
+		subject := newSubject()
+		mockSplitTracker.EXPECT().
+			Track("user-1", DefaultTrafficType, "checkout", 9.99, map[string]interface{}{"currency": "EUR"}).
+			Return(nil)
+
+		// act
+		subject.Track(context.Background(), "checkout",
+			openfeature.NewEvaluationContext("user-1", map[string]any{"plan": "pro"}),
+			openfeature.NewTrackingEventDetails(9.99).Add("currency", "EUR"))
+	})
+
+	It("tracks the event for the key of the traffic type named by the context", func() {
+		subject := newSubject(WithTrafficTypes(TrafficTypes{Keys: map[string]string{"account": "accountId"}}))
+		mockSplitTracker.EXPECT().Track("account-1", "account", "upgrade", 0.0, nil).Return(nil)
+
+		// act
+		subject.Track(context.Background(), "upgrade",
+			openfeature.NewEvaluationContext("user-1", map[string]any{TrafficTypeKey: "account", "accountId": "account-1"}),
+			openfeature.NewTrackingEventDetails(0))
+	})
+
+	It("filters and hashes the properties like evaluation attributes", func() {
+		subject := newSubject(WithAttributeFilter(AttributeFilter{
+			Deny:     []string{"ip"},
+			Hash:     []string{"email"},
+			HashSalt: "salt:",
+		}))
+		sum := sha256.Sum256([]byte("salt:jane@example.com"))
+		mockSplitTracker.EXPECT().
+			Track("user-1", DefaultTrafficType, "signup", 1.0, map[string]interface{}{
+				"email": hex.EncodeToString(sum[:]),
+				"plan":  "pro",
+			}).
+			Return(nil)
+
+		// act
+		subject.Track(context.Background(), "signup",
+			openfeature.NewEvaluationContext("user-1", nil),
+			openfeature.NewTrackingEventDetails(1).Add("email", "jane@example.com").Add("ip", "10.0.0.1").Add("plan", "pro"))
+	})
+
+	It("drops events without a key", func() {
+		subject := newSubject(WithAnonymousEvaluation(AnonymousConfig{Key: "anonymous"}))
+
+		// act
+		subject.Track(context.Background(), "signup",
+			openfeature.NewEvaluationContext("", nil),
+			openfeature.NewTrackingEventDetails(1))
+	})
+
+	It("does nothing when the Split client cannot track events", func() {
+		subject, err := NewProvider(mockSplitClient)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		// act
+		subject.Track(context.Background(), "signup",
+			openfeature.NewEvaluationContext("user-1", nil),
+			openfeature.NewTrackingEventDetails(1))
+	})
+})
@@ -0,0 +1,128 @@
+package fork_split_openfeature_provider_go
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// initReadyTimeout is how long, in seconds, Init waits for the Split SDK before validating required flags.
+const initReadyTimeout = 10
+
+// ExpectedFlag declares a flag the application evaluates and the OpenFeature type it evaluates it as.
+type ExpectedFlag struct {
+	Name string
+	Type openfeature.Type
+}
+
+// FlagProblem describes why an expected flag cannot be evaluated as declared.
+type FlagProblem struct {
+	Flag string
+	Type openfeature.Type
+	// Missing is true when the flag does not exist in Split.
+	Missing bool
+	// InvalidTreatments lists the possible treatments of the flag that do not parse as Type.
+	InvalidTreatments []string
+}
+
+func (problem FlagProblem) String() string {
+	if problem.Missing {
+		return fmt.Sprintf("flag %q does not exist", problem.Flag)
+	}
+	return fmt.Sprintf("flag %q has treatments that are not %s: %s",
+		problem.Flag, problem.Type, strings.Join(problem.InvalidTreatments, ", "))
+}
+
+// ValidationReport is the outcome of checking expected flags against Split.
+type ValidationReport struct {
+	Checked  int
+	Problems []FlagProblem
+}
+
+// OK reports whether every expected flag exists and all of its treatments parse as its type.
+func (report ValidationReport) OK() bool {
+	return len(report.Problems) == 0
+}
+
+// Err returns an error describing every problem, or nil when the report is OK.
+func (report ValidationReport) Err() error {
+	if report.OK() {
+		return nil
+	}
+	problems := make([]string, 0, len(report.Problems))
+	for _, problem := range report.Problems {
+		problems = append(problems, problem.String())
+	}
+	return fmt.Errorf("%d of %d expected flags are invalid: %s",
+		len(report.Problems), report.Checked, strings.Join(problems, "; "))
+}
+
+// ValidateFlags checks, using the Split manager, that each expected flag exists and that every treatment
+// it can serve parses as its declared type.
+func (provider *SplitProvider) ValidateFlags(expected ...ExpectedFlag) (ValidationReport, error) {
+	report := ValidationReport{Checked: len(expected)}
+	for _, want := range expected {
+		flag, ok, err := provider.Flag(want.Name)
+		if err != nil {
+			return ValidationReport{}, err
+		}
+		if !ok {
+			report.Problems = append(report.Problems, FlagProblem{Flag: want.Name, Type: want.Type, Missing: true})
+			continue
+		}
+		var invalid []string
+		for _, treatment := range flag.Treatments {
+			if !treatmentParses(treatment, want.Type) {
+				invalid = append(invalid, treatment)
+			}
+		}
+		if len(invalid) > 0 {
+			report.Problems = append(report.Problems, FlagProblem{Flag: want.Name, Type: want.Type, InvalidTreatments: invalid})
+		}
+	}
+	return report, nil
+}
+
+// WithRequiredFlags makes Init wait for the Split SDK and fail unless every expected flag passes ValidateFlags.
+// It requires a Split manager.
+func WithRequiredFlags(expected ...ExpectedFlag) Option {
+	return func(provider *SplitProvider) error {
+		provider.required = append(provider.required, expected...)
+		return nil
+	}
+}
+
+func (provider *SplitProvider) validateRequiredFlags() error {
+	if len(provider.required) == 0 {
+		return nil
+	}
+	if provider.factory != nil && !provider.factory.IsReady() {
+		if err := provider.factory.BlockUntilReady(initReadyTimeout); err != nil {
+			return err
+		}
+	}
+	report, err := provider.ValidateFlags(provider.required...)
+	if err != nil {
+		return err
+	}
+	return report.Err()
+}
+
+// treatmentParses reports whether the typed evaluation of flagType would accept treatment.
+func treatmentParses(treatment string, flagType openfeature.Type) bool {
+	var ok bool
+	switch flagType {
+	case openfeature.Boolean:
+		_, ok = parseBool(treatment)
+	case openfeature.Int:
+		_, ok = parseInt(treatment)
+	case openfeature.Float:
+		_, ok = parseFloat(treatment)
+	case openfeature.Object:
+		_, ok = parseObject(treatment)
+	default:
+		ok = true
+	}
+	return ok
+}
@@ -0,0 +1,147 @@
+package fork_split_openfeature_provider_go_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-feature/go-sdk/openfeature"
+	. "github.com/snap-one/fork-split-openfeature-provider-go"
+	"github.com/snap-one/fork-split-openfeature-provider-go/mocks"
+	"github.com/splitio/go-client/splitio/client"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Flag validation", func() {
+	var (
+		mockSplitClient  *mocks.MockSplitClient
+		mockSplitFactory *mocks.MockSplitFactory
+		mockSplitManager *mocks.MockSplitManager
+	)
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockSplitClient = mocks.NewMockSplitClient(mockCtrl)
+		mockSplitFactory = mocks.NewMockSplitFactory(mockCtrl)
+		mockSplitManager = mocks.NewMockSplitManager(mockCtrl)
+		splits := map[string]*client.SplitView{
+			"checkout": {Name: "checkout", Treatments: []string{"on", "off"}},
+			"limit":    {Name: "limit", Treatments: []string{"10", "20", "lots"}},
+			"ratio":    {Name: "ratio", Treatments: []string{"0.5", "1"}},
+			"theme":    {Name: "theme", Treatments: []string{`{"color":"red"}`, "plain"}},
+		}
+		mockSplitManager.EXPECT().Split(gomock.Any()).DoAndReturn(func(name string) *client.SplitView {
+			return splits[name]
+		}).AnyTimes()
+	})
+
+	Describe("ValidateFlags", func() {
+		It("reports missing flags and treatments of the wrong type", func() {
+			subject, err := NewProvider(mockSplitClient, WithSplitManager(mockSplitManager))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// act
+			report, err := subject.ValidateFlags(
+				ExpectedFlag{Name: "checkout", Type: openfeature.Boolean},
+				ExpectedFlag{Name: "chekout", Type: openfeature.Boolean},
+				ExpectedFlag{Name: "limit", Type: openfeature.Int},
+				ExpectedFlag{Name: "ratio", Type: openfeature.Float},
+				ExpectedFlag{Name: "theme", Type: openfeature.Object},
+				ExpectedFlag{Name: "theme", Type: openfeature.String},
+			)
+
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(report.OK()).Should(BeFalse())
+			Ω(report).Should(Equal(ValidationReport{
+				Checked: 6,
+				Problems: []FlagProblem{
+					{Flag: "chekout", Type: openfeature.Boolean, Missing: true},
+					{Flag: "limit", Type: openfeature.Int, InvalidTreatments: []string{"lots"}},
+					{Flag: "theme", Type: openfeature.Object, InvalidTreatments: []string{"plain"}},
+				},
+			}))
+			Ω(report.Err()).Should(MatchError(`3 of 6 expected flags are invalid: ` +
+				`flag "chekout" does not exist; ` +
+				`flag "limit" has treatments that are not int: lots; ` +
+				`flag "theme" has treatments that are not object: plain`))
+		})
+
+		It("passes when every flag is valid", func() {
+			subject, err := NewProvider(mockSplitClient, WithSplitManager(mockSplitManager))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// act
+			report, err := subject.ValidateFlags(ExpectedFlag{Name: "checkout", Type: openfeature.Boolean})
+
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(report.OK()).Should(BeTrue())
+			Ω(report.Err()).ShouldNot(HaveOccurred())
+		})
+
+		It("fails without a split manager", func() {
+			subject, err := NewProvider(mockSplitClient)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// act
+			_, err = subject.ValidateFlags(ExpectedFlag{Name: "checkout", Type: openfeature.Boolean})
+
+			Ω(err).Should(MatchError(ErrNoSplitManager))
+		})
+	})
+
+	Describe("WithRequiredFlags", func() {
+		It("fails Init when a required flag is invalid", func() {
+			mockSplitFactory.EXPECT().IsReady().Return(true)
+			subject, err := NewProvider(mockSplitClient,
+				WithSplitFactory(mockSplitFactory),
+				WithSplitManager(mockSplitManager),
+				WithRequiredFlags(ExpectedFlag{Name: "limit", Type: openfeature.Int}))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// act
+			err = subject.Init(openfeature.EvaluationContext{})
+
+			Ω(err).Should(MatchError(ContainSubstring(`flag "limit" has treatments that are not int: lots`)))
+		})
+
+		It("waits for the SDK before validating", func() {
+			gomock.InOrder(
+				mockSplitFactory.EXPECT().IsReady().Return(false),
+				mockSplitFactory.EXPECT().BlockUntilReady(gomock.Any()).Return(nil),
+			)
+			subject, err := NewProvider(mockSplitClient,
+				WithSplitFactory(mockSplitFactory),
+				WithSplitManager(mockSplitManager),
+				WithRequiredFlags(ExpectedFlag{Name: "checkout", Type: openfeature.Boolean}))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// act
+			err = subject.Init(openfeature.EvaluationContext{})
+
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("fails Init when the SDK does not become ready", func() {
+			timeout := errors.New("SDK Initialization: time of 10 exceeded")
+			mockSplitFactory.EXPECT().IsReady().Return(false)
+			mockSplitFactory.EXPECT().BlockUntilReady(gomock.Any()).Return(timeout)
+			subject, err := NewProvider(mockSplitClient,
+				WithSplitFactory(mockSplitFactory),
+				WithSplitManager(mockSplitManager),
+				WithRequiredFlags(ExpectedFlag{Name: "checkout", Type: openfeature.Boolean}))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// act
+			err = subject.Init(openfeature.EvaluationContext{})
+
+			Ω(err).Should(MatchError(timeout))
+		})
+
+		It("does nothing on Init without required flags", func() {
+			subject, err := NewProvider(mockSplitClient)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(subject.Init(openfeature.EvaluationContext{})).Should(Succeed())
+		})
+	})
+})